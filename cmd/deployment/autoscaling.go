@@ -0,0 +1,335 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deploymentsize"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const autoscalingExample = `## Show the autoscaling status and the sizes of each Elasticsearch tier.
+$ ecctl deployment autoscaling show 5c17ad7c8df73206baa54b6e2829d9bc
+DEPLOYMENT ID                      AUTOSCALING
+5c17ad7c8df73206baa54b6e2829d9bc   disabled
+
+TIER          SIZE   ZONES   MIN SIZE   MAX SIZE
+hot_content   8g     2       -          116g
+ml            0g     1       0g         60g
+
+## Enable autoscaling and wait for the change to finish.
+$ ecctl deployment autoscaling enable 5c17ad7c8df73206baa54b6e2829d9bc --track
+
+## Allow the hot tier to autoscale up to 64g per zone.
+$ ecctl deployment autoscaling set 5c17ad7c8df73206baa54b6e2829d9bc --tier hot_content --max-size 64g`
+
+var errNoAutoscalingSizeFlags = errors.New("at least one of --max-size or --min-size must be specified")
+
+// deploymentAutoscaling summarises the autoscaling settings of a deployment's
+// Elasticsearch resource.
+type deploymentAutoscaling struct {
+	ID      string            `json:"id"`
+	Enabled bool              `json:"enabled"`
+	Tiers   []tierAutoscaling `json:"tiers"`
+}
+
+// tierAutoscaling contains the current size and the autoscaling limits of an
+// Elasticsearch tier. Sizes use the <size>g notation.
+type tierAutoscaling struct {
+	ID      string `json:"id"`
+	Size    string `json:"size,omitempty"`
+	Zones   int32  `json:"zones"`
+	MinSize string `json:"min_size,omitempty"`
+	MaxSize string `json:"max_size,omitempty"`
+}
+
+var autoscalingCmd = &cobra.Command{
+	Use:     "autoscaling",
+	Short:   "Manages the autoscaling of a deployment's Elasticsearch tiers",
+	Example: autoscalingExample,
+	PreRunE: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var autoscalingShowCmd = &cobra.Command{
+	Use:     "show <deployment id>",
+	Short:   "Shows the autoscaling status and the size limits of each Elasticsearch tier",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := getAutoscalingUpdateRequest(args[0])
+		if err != nil {
+			return err
+		}
+
+		autoscaling, err := newDeploymentAutoscaling(args[0], req.Resources.Elasticsearch)
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/autoscaling", autoscaling)
+	},
+}
+
+var autoscalingEnableCmd = &cobra.Command{
+	Use:     "enable <deployment id>",
+	Short:   "Enables autoscaling for a deployment",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAutoscalingEnabled(cmd, args[0], true)
+	},
+}
+
+var autoscalingDisableCmd = &cobra.Command{
+	Use:     "disable <deployment id>",
+	Short:   "Disables autoscaling for a deployment",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAutoscalingEnabled(cmd, args[0], false)
+	},
+}
+
+var autoscalingSetCmd = &cobra.Command{
+	Use:     "set <deployment id> [--tier <id>] {--max-size <size> | --min-size <size>}",
+	Short:   "Sets the autoscaling size limits of an Elasticsearch tier",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tier, _ := cmd.Flags().GetString("tier")
+		maxSize, minSize, err := parseAutoscalingSizeFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		res, err := getDeploymentForUpdate(args[0])
+		if err != nil {
+			return err
+		}
+
+		tpl, err := getDeploymentTemplate(res)
+		if err != nil {
+			return err
+		}
+		if tpl == nil {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), noDeploymentTemplateWarning)
+		}
+
+		req := newElasticsearchUpdateRequest(res)
+		if err := applyAutoscalingSizes(newTopologyConstraints(tpl), req.Resources.Elasticsearch,
+			tier, maxSize, minSize,
+		); err != nil {
+			return err
+		}
+
+		return updateTopology(cmd, args[0], req)
+	},
+}
+
+func init() {
+	Command.AddCommand(autoscalingCmd)
+	autoscalingCmd.AddCommand(
+		autoscalingShowCmd,
+		autoscalingEnableCmd,
+		autoscalingDisableCmd,
+		autoscalingSetCmd,
+	)
+	initAutoscalingFlags()
+}
+
+func initAutoscalingFlags() {
+	autoscalingEnableCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	autoscalingDisableCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	autoscalingSetCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	autoscalingSetCmd.Flags().String("tier", defaultTopologyTier, "Elasticsearch topology element ID whose autoscaling limits are set")
+	autoscalingSetCmd.Flags().String("max-size", "", "Maximum memory size per zone the tier can be autoscaled to, such as 64g")
+	autoscalingSetCmd.Flags().String("min-size", "", "Minimum memory size per zone the tier can be autoscaled to, such as 1g. Only supported by tiers with an autoscale min size, such as ml")
+}
+
+// parseAutoscalingSizeFlags reads and parses the --max-size and --min-size
+// flags, at least one of which must be set.
+func parseAutoscalingSizeFlags(cmd *cobra.Command) (maxSize, minSize *int32, err error) {
+	var merr = multierror.NewPrefixed("invalid autoscaling flags")
+	for name, dst := range map[string]**int32{
+		"max-size": &maxSize,
+		"min-size": &minSize,
+	} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		raw, _ := cmd.Flags().GetString(name)
+		size, err := deploymentsize.ParseGb(raw)
+		if err != nil {
+			merr = merr.Append(fmt.Errorf("--%s: %w", name, err))
+			continue
+		}
+		*dst = ec.Int32(size)
+	}
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+
+	if maxSize == nil && minSize == nil {
+		return nil, nil, errNoAutoscalingSizeFlags
+	}
+	return maxSize, minSize, nil
+}
+
+// getAutoscalingUpdateRequest returns a minimal update request for the
+// deployment, failing when it has no Elasticsearch resource.
+func getAutoscalingUpdateRequest(id string) (*models.DeploymentUpdateRequest, error) {
+	res, err := getDeploymentForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := newElasticsearchUpdateRequest(res)
+	if len(req.Resources.Elasticsearch) == 0 || req.Resources.Elasticsearch[0].Plan == nil {
+		return nil, errors.New("the deployment has no Elasticsearch resource")
+	}
+	return req, nil
+}
+
+// newElasticsearchUpdateRequest builds a minimal update request from the
+// deployment, which only contains its Elasticsearch resources.
+func newElasticsearchUpdateRequest(res *models.DeploymentGetResponse) *models.DeploymentUpdateRequest {
+	req := deploymentapi.NewUpdateRequest(res)
+	req.Resources = &models.DeploymentUpdateResources{
+		Elasticsearch: req.Resources.Elasticsearch,
+	}
+	req.Settings = nil
+	return req
+}
+
+// newDeploymentAutoscaling builds the autoscaling summary of the first
+// Elasticsearch resource.
+func newDeploymentAutoscaling(id string, es []*models.ElasticsearchPayload) (deploymentAutoscaling, error) {
+	if len(es) == 0 || es[0].Plan == nil {
+		return deploymentAutoscaling{}, errors.New("the deployment has no Elasticsearch resource")
+	}
+
+	plan := es[0].Plan
+	var result = deploymentAutoscaling{
+		ID:      id,
+		Enabled: plan.AutoscalingEnabled != nil && *plan.AutoscalingEnabled,
+		Tiers:   make([]tierAutoscaling, 0, len(plan.ClusterTopology)),
+	}
+	for _, t := range plan.ClusterTopology {
+		var tier = tierAutoscaling{ID: t.ID, Zones: t.ZoneCount}
+		if v := topologySizeValue(t.Size); v != nil {
+			tier.Size = formatTopologySize(*v)
+		}
+		if v := topologySizeValue(t.AutoscalingMin); v != nil {
+			tier.MinSize = formatTopologySize(*v)
+		}
+		if v := topologySizeValue(t.AutoscalingMax); v != nil {
+			tier.MaxSize = formatTopologySize(*v)
+		}
+		result.Tiers = append(result.Tiers, tier)
+	}
+
+	return result, nil
+}
+
+// setAutoscalingEnabled enables or disables autoscaling for the deployment. No
+// update is made when autoscaling is already in the requested state.
+func setAutoscalingEnabled(cmd *cobra.Command, id string, enabled bool) error {
+	req, err := getAutoscalingUpdateRequest(id)
+	if err != nil {
+		return err
+	}
+
+	var state = "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	plan := req.Resources.Elasticsearch[0].Plan
+	if current := plan.AutoscalingEnabled != nil && *plan.AutoscalingEnabled; current == enabled {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Autoscaling is already %s for deployment %s\n", state, id)
+		return nil
+	}
+
+	plan.AutoscalingEnabled = ec.Bool(enabled)
+	return updateTopology(cmd, id, req)
+}
+
+// applyAutoscalingSizes sets the autoscaling limits of the tier, validating
+// them against the tier's current size and the specified constraints.
+func applyAutoscalingSizes(c topologyConstraints, es []*models.ElasticsearchPayload, tier string, maxSize, minSize *int32) error {
+	var merr = multierror.NewPrefixed("invalid autoscaling flags")
+	if maxSize != nil {
+		flags := topologyFlags{tier: tier, autoscaleMax: maxSize}
+		if err := applyElasticsearchTopology(flags, c, es); err != nil {
+			return merr.Append(err)
+		}
+	}
+
+	element, err := findTopologyElement(es, tier)
+	if err != nil {
+		return merr.Append(err)
+	}
+
+	if minSize != nil {
+		if err := validateAutoscaleMin(c, element, *minSize); err != nil {
+			merr = merr.Append(fmt.Errorf("tier %s: %w", tier, err))
+		}
+		element.AutoscalingMin = newTopologySize(element.AutoscalingMin, *minSize)
+	}
+
+	min, max := topologySizeValue(element.AutoscalingMin), topologySizeValue(element.AutoscalingMax)
+	if min != nil && max != nil && *min > *max {
+		merr = merr.Append(fmt.Errorf("tier %s: autoscale min size %s is higher than the autoscale max size %s",
+			tier, formatTopologySize(*min), formatTopologySize(*max),
+		))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// validateAutoscaleMin validates the autoscale min size of the topology element
+// against the constraints. Tiers without an autoscale min size, either in the
+// deployment template or in the deployment, can't be scaled down automatically,
+// so the autoscale min size can't be set for them.
+func validateAutoscaleMin(c topologyConstraints, element *models.ElasticsearchClusterTopologyElement, size int32) error {
+	min, hasMin := c.autoscaleMin[element.ID]
+	if !hasMin && element.AutoscalingMin == nil {
+		return errors.New("the tier doesn't support an autoscale min size")
+	}
+
+	if size < min {
+		return fmt.Errorf("autoscale min size %s is lower than the minimum allowed size %s",
+			formatTopologySize(size), formatTopologySize(min),
+		)
+	}
+	if max, ok := c.autoscaleMax[element.ID]; ok && size > max {
+		return fmt.Errorf("autoscale min size %s is higher than the maximum allowed size %s",
+			formatTopologySize(size), formatTopologySize(max),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+const autoscalingDeploymentID = "5c17ad7c8df73206baa54b6e2829d9bc"
+
+func newAutoscalingTestDeployment() *models.DeploymentGetResponse {
+	return &models.DeploymentGetResponse{
+		ID:   ec.String(autoscalingDeploymentID),
+		Name: ec.String("my-deployment"),
+		Resources: &models.DeploymentResources{
+			Elasticsearch: []*models.ElasticsearchResourceInfo{{
+				ID:     ec.String("3531aaf988594efa87c1aabb7caed337"),
+				RefID:  ec.String("main-elasticsearch"),
+				Region: ec.String("us-east-1"),
+				Info: &models.ElasticsearchClusterInfo{
+					ClusterName: ec.String("my-deployment"),
+					PlanInfo: &models.ElasticsearchClusterPlansInfo{
+						Current: &models.ElasticsearchClusterPlanInfo{
+							Plan: &models.ElasticsearchClusterPlan{
+								AutoscalingEnabled: ec.Bool(false),
+								Elasticsearch:      &models.ElasticsearchConfiguration{Version: "8.13.2"},
+								ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+									{
+										ID:             "hot_content",
+										ZoneCount:      2,
+										Size:           &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(8192)},
+										AutoscalingMax: &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(118784)},
+									},
+									{
+										ID:             "ml",
+										ZoneCount:      1,
+										Size:           &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(0)},
+										AutoscalingMin: &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(0)},
+										AutoscalingMax: &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(61440)},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func newAutoscalingUpdateAssertion(body string) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Method: "PUT",
+			Path:   "/api/v1/deployments/" + autoscalingDeploymentID,
+			Host:   api.DefaultMockHost,
+			Query: url.Values{
+				"hide_pruned_orphans": {"false"},
+				"skip_snapshot":       {"false"},
+				"validate_only":       {"false"},
+			},
+			Body: mock.NewStringBody(body + "\n"),
+		},
+		mock.NewStringBody(`{"id":"`+autoscalingDeploymentID+`"}`),
+	)
+}
+
+func Test_autoscalingCmd(t *testing.T) {
+	var updateOutput = "{\n  \"id\": \"" + autoscalingDeploymentID + "\",\n  \"name\": null,\n  \"resources\": null\n}\n"
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "shows the autoscaling status and tier sizes",
+			args: testutils.Args{
+				Cmd:  autoscalingShowCmd,
+				Args: []string{"autoscaling", "show", autoscalingDeploymentID},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses:    []mock.Response{mock.New200StructResponse(newAutoscalingTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "DEPLOYMENT ID                      AUTOSCALING\n" +
+					autoscalingDeploymentID + "   disabled\n\n" +
+					"TIER          SIZE   ZONES   MIN SIZE   MAX SIZE\n" +
+					"hot_content   8g     2       -          116g\n" +
+					"ml            0g     1       0g         60g\n",
+			},
+		},
+		{
+			name: "enables autoscaling",
+			args: testutils.Args{
+				Cmd:  autoscalingEnableCmd,
+				Args: []string{"autoscaling", "enable", autoscalingDeploymentID},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						mock.New200StructResponse(newAutoscalingTestDeployment()),
+						newAutoscalingUpdateAssertion(`{"name":"my-deployment","prune_orphans":false,"resources":{"apm":null,"appsearch":null,` +
+							`"elasticsearch":[{"display_name":"my-deployment","plan":{"autoscaling_enabled":true,"cluster_topology":[` +
+							`{"autoscaling_max":{"resource":"memory","value":118784},"id":"hot_content","node_roles":null,"size":{"resource":"memory","value":8192},"zone_count":2},` +
+							`{"autoscaling_max":{"resource":"memory","value":61440},"autoscaling_min":{"resource":"memory","value":0},"id":"ml","node_roles":null,"size":{"resource":"memory","value":0},"zone_count":1}],` +
+							`"elasticsearch":{"version":"8.13.2"}},"ref_id":"main-elasticsearch","region":"us-east-1"}],` +
+							`"enterprise_search":null,"integrations_server":null,"kibana":null}}`),
+					},
+				},
+			},
+			want: testutils.Assertion{Stdout: updateOutput},
+		},
+		{
+			name: "doesn't update the deployment when autoscaling is already disabled",
+			args: testutils.Args{
+				Cmd:  autoscalingDisableCmd,
+				Args: []string{"autoscaling", "disable", autoscalingDeploymentID},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newAutoscalingTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "Autoscaling is already disabled for deployment " + autoscalingDeploymentID + "\n",
+			},
+		},
+		{
+			name: "fails when no sizes are specified",
+			args: testutils.Args{
+				Cmd:  autoscalingSetCmd,
+				Args: []string{"autoscaling", "set", autoscalingDeploymentID},
+			},
+			want: testutils.Assertion{Err: errNoAutoscalingSizeFlags.Error()},
+		},
+		{
+			name: "fails when the sizes are invalid",
+			args: testutils.Args{
+				Cmd:  autoscalingSetCmd,
+				Args: []string{"autoscaling", "set", autoscalingDeploymentID, "--tier", "ml", "--min-size", "32g", "--max-size", "16g"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newAutoscalingTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{
+				Stderr: noDeploymentTemplateWarning + "\n",
+				Err: "invalid autoscaling flags: 1 error occurred:\n" +
+					"\t* tier ml: autoscale min size 32g is higher than the autoscale max size 16g\n\n",
+			},
+		},
+		{
+			name: "fails when the tier doesn't support an autoscale min size",
+			args: testutils.Args{
+				Cmd:  autoscalingSetCmd,
+				Args: []string{"autoscaling", "set", autoscalingDeploymentID, "--min-size", "1g"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newAutoscalingTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{
+				Stderr: noDeploymentTemplateWarning + "\n",
+				Err: "invalid autoscaling flags: 1 error occurred:\n" +
+					"\t* tier hot_content: the tier doesn't support an autoscale min size\n\n",
+			},
+		},
+		{
+			name: "sets the autoscaling limits of a tier",
+			args: testutils.Args{
+				Cmd:  autoscalingSetCmd,
+				Args: []string{"autoscaling", "set", autoscalingDeploymentID, "--tier", "ml", "--min-size", "1g", "--max-size", "30g"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						mock.New200StructResponse(newAutoscalingTestDeployment()),
+						newAutoscalingUpdateAssertion(`{"name":"my-deployment","prune_orphans":false,"resources":{"apm":null,"appsearch":null,` +
+							`"elasticsearch":[{"display_name":"my-deployment","plan":{"autoscaling_enabled":false,"cluster_topology":[` +
+							`{"autoscaling_max":{"resource":"memory","value":118784},"id":"hot_content","node_roles":null,"size":{"resource":"memory","value":8192},"zone_count":2},` +
+							`{"autoscaling_max":{"resource":"memory","value":30720},"autoscaling_min":{"resource":"memory","value":1024},"id":"ml","node_roles":null,"size":{"resource":"memory","value":0},"zone_count":1}],` +
+							`"elasticsearch":{"version":"8.13.2"}},"ref_id":"main-elasticsearch","region":"us-east-1"}],` +
+							`"enterprise_search":null,"integrations_server":null,"kibana":null}}`),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: updateOutput,
+				Stderr: noDeploymentTemplateWarning + "\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			for _, cmd := range []*cobra.Command{autoscalingEnableCmd, autoscalingDisableCmd, autoscalingSetCmd} {
+				cmd.ResetFlags()
+			}
+			defer initAutoscalingFlags()
+		})
+	}
+}
+
+func Test_validateAutoscaleMin(t *testing.T) {
+	var c = topologyConstraints{
+		autoscaleMin: map[string]int32{"ml": 0, "frozen": 4096},
+		autoscaleMax: map[string]int32{"ml": 61440, "frozen": 122880},
+	}
+	tests := []struct {
+		name    string
+		element *models.ElasticsearchClusterTopologyElement
+		size    int32
+		err     string
+	}{
+		{
+			name:    "succeeds within the template limits",
+			element: &models.ElasticsearchClusterTopologyElement{ID: "ml"},
+			size:    1024,
+		},
+		{
+			name:    "fails below the template minimum",
+			element: &models.ElasticsearchClusterTopologyElement{ID: "frozen"},
+			size:    1024,
+			err:     "autoscale min size 1g is lower than the minimum allowed size 4g",
+		},
+		{
+			name:    "fails above the template maximum",
+			element: &models.ElasticsearchClusterTopologyElement{ID: "ml"},
+			size:    65536,
+			err:     "autoscale min size 64g is higher than the maximum allowed size 60g",
+		},
+		{
+			name:    "fails when the tier has no autoscale min size",
+			element: &models.ElasticsearchClusterTopologyElement{ID: "hot_content"},
+			size:    1024,
+			err:     "the tier doesn't support an autoscale min size",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAutoscaleMin(c, tt.element, tt.size)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const bulkLong = `Runs the same action across multiple deployments. The target deployments are either
+the matches of a search query specified with --query-file, or the deployment IDs read
+from stdin, separated by spaces or new lines.
+
+The list of target deployments is displayed and a single confirmation is asked for
+before any action is performed, unless --force is set. Since stdin is used to read the
+deployment IDs when they are not obtained from a query file, the confirmation is read
+from the terminal instead.
+
+The actions are run with the concurrency set by --concurrency, displaying the progress
+of each deployment as it finishes. Once all the actions have finished, a summary of the
+successes and failures is displayed, and a non-zero exit code is returned when any of
+the actions failed.`
+
+const bulkExample = `## Restart Kibana in all the deployments matching a query.
+$ ecctl deployment bulk restart --kind kibana --query-file query.json
+
+## Shut down the deployments whose IDs are listed in a file, 10 at a time.
+$ cat ids.txt | ecctl deployment bulk shutdown --concurrency 10 --force
+
+## Associate a traffic filter to all the deployments matching a query.
+$ ecctl deployment bulk associate-traffic-filter --ruleset-id 11a2bd9da8e64da4b6a8a7ef0df4e14b --query-file query.json`
+
+const defaultBulkConcurrency = 5
+
+// bulkTarget represents a deployment targeted by a bulk action.
+type bulkTarget struct {
+	ID   string
+	Name string
+}
+
+// bulkResult represents the outcome of a bulk action over a deployment.
+type bulkResult struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// bulkSummary contains the outcome of a bulk action over all its targets.
+type bulkSummary struct {
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []bulkResult `json:"results"`
+}
+
+// bulkAction is the action run against each of the targeted deployments.
+type bulkAction func(id string) error
+
+var bulkCmd = &cobra.Command{
+	Use:     "bulk",
+	Short:   "Runs an action across multiple deployments",
+	Long:    bulkLong,
+	Example: bulkExample,
+	PreRunE: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var bulkRestartCmd = &cobra.Command{
+	Use:     "restart --kind <kind> {--query-file <file> | < ids}",
+	Short:   "Restarts a resource kind in multiple deployments",
+	PreRunE: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("kind")
+		return runBulkCmd(cmd, fmt.Sprintf("restart %s", kind), func(id string) error {
+			return restartResource(depresourceapi.Params{
+				API:          ecctl.Get().API,
+				DeploymentID: id,
+				Kind:         kind,
+			})
+		})
+	},
+}
+
+var bulkResyncCmd = &cobra.Command{
+	Use:     "resync {--query-file <file> | < ids}",
+	Short:   "Resynchronizes the search index and cache of multiple deployments",
+	PreRunE: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulkCmd(cmd, "resync", func(id string) error {
+			return deploymentapi.Resync(deploymentapi.ResyncParams{
+				API: ecctl.Get().API,
+				ID:  id,
+			})
+		})
+	},
+}
+
+var bulkShutdownCmd = &cobra.Command{
+	Use:     "shutdown {--query-file <file> | < ids}",
+	Short:   "Shuts down multiple deployments and all of their associated sub-resources",
+	PreRunE: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		skipSnapshot, _ := cmd.Flags().GetBool("skip-snapshot")
+		return runBulkCmd(cmd, "shut down", func(id string) error {
+			_, err := deploymentapi.Shutdown(deploymentapi.ShutdownParams{
+				API:          ecctl.Get().API,
+				DeploymentID: id,
+				SkipSnapshot: skipSnapshot,
+			})
+			return err
+		})
+	},
+}
+
+var bulkAssociateTrafficFilterCmd = &cobra.Command{
+	Use:     "associate-traffic-filter --ruleset-id <id> {--query-file <file> | < ids}",
+	Short:   "Associates a traffic filter rule set to multiple deployments",
+	PreRunE: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rulesetID, _ := cmd.Flags().GetString("ruleset-id")
+		return runBulkCmd(cmd, fmt.Sprintf("associate traffic filter %s to", rulesetID), func(id string) error {
+			return trafficfilterapi.CreateAssociation(trafficfilterapi.CreateAssociationParams{
+				API:        ecctl.Get().API,
+				ID:         rulesetID,
+				EntityID:   id,
+				EntityType: "deployment",
+			})
+		})
+	},
+}
+
+func init() {
+	initBulkFlags()
+}
+
+func initBulkFlags() {
+	Command.AddCommand(bulkCmd)
+	bulkCmd.AddCommand(
+		bulkRestartCmd,
+		bulkResyncCmd,
+		bulkShutdownCmd,
+		bulkAssociateTrafficFilterCmd,
+	)
+
+	bulkCmd.PersistentFlags().StringP("query-file", "f", "", "JSON file that contains the Query DSL query used to select the deployments, if not set, the deployment IDs are read from stdin")
+	bulkCmd.PersistentFlags().Int("concurrency", defaultBulkConcurrency, "Maximum number of deployments the action is run against in parallel")
+
+	cmdutil.AddKindFlag(bulkRestartCmd, "Required", true)
+	bulkRestartCmd.MarkFlagRequired("kind")
+
+	bulkShutdownCmd.Flags().Bool("skip-snapshot", false, "Skips taking an Elasticsearch snapshot prior to shutting down the deployments")
+
+	bulkAssociateTrafficFilterCmd.Flags().String("ruleset-id", "", "ID of the traffic filter rule set to associate")
+	bulkAssociateTrafficFilterCmd.MarkFlagRequired("ruleset-id")
+}
+
+// runBulkCmd obtains the bulk targets, asks for confirmation and runs the
+// action against all of them, formatting a summary of the results.
+func runBulkCmd(cmd *cobra.Command, description string, action bulkAction) error {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+
+	queryFile, _ := cmd.Flags().GetString("query-file")
+	var targets []bulkTarget
+	var err error
+	if queryFile != "" {
+		targets, err = searchBulkTargets(queryFile)
+	} else {
+		targets, err = readBulkTargets(cmd.InOrStdin())
+	}
+	if err != nil {
+		return err
+	}
+
+	// Stdin has already been consumed by the deployment IDs, so the
+	// confirmation is read from the terminal.
+	var confirm io.Reader = os.Stdin
+	if force, _ := cmd.Flags().GetBool("force"); !force && queryFile == "" && len(targets) > 0 {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return fmt.Errorf("unable to open the terminal to confirm the action, use --force to skip the confirmation: %w", err)
+		}
+		defer tty.Close()
+		confirm = tty
+	}
+
+	return runBulkTargets(cmd, targets, concurrency, description, confirm, action)
+}
+
+// runBulkTargets asks for confirmation, reading the answer from confirm, and
+// runs the action against all the targets, formatting a summary of the results.
+// An error is returned when the action fails for any of the targets.
+func runBulkTargets(cmd *cobra.Command, targets []bulkTarget, concurrency int, description string, confirm io.Reader, action bulkAction) error {
+	if len(targets) == 0 {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No deployments matched, nothing to do")
+		return nil
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	if !force {
+		device := ecctl.Get().Config.OutputDevice
+		_, _ = fmt.Fprintf(device, "The following %d deployments will be affected:\n", len(targets))
+		for _, t := range targets {
+			_, _ = fmt.Fprintf(device, "  %s %s\n", t.ID, t.Name)
+		}
+
+		msg := fmt.Sprintf("Do you want to %s %d deployments? [y/n]: ", description, len(targets))
+		if !sdkcmdutil.ConfirmAction(msg, confirm, device) {
+			return nil
+		}
+	}
+
+	summary := runBulk(targets, concurrency, cmd.ErrOrStderr(), action)
+	if err := ecctl.Get().Formatter.Format("deployment/bulk", summary); err != nil {
+		return err
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d deployments failed", summary.Failed, len(targets))
+	}
+
+	return nil
+}
+
+// searchBulkTargets returns all the deployments matching the query, using a
+// cursor to page through the results.
+func searchBulkTargets(queryFile string) ([]bulkTarget, error) {
+	sr, err := sdkcmdutil.ParseQueryDSLFile(queryFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// A sort field is required to obtain all the matches with a cursor.
+	if sr.Sort == nil {
+		sr.Sort = []interface{}{"id"}
+	}
+
+	res, err := searchAllDeployments(&sr)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets = make([]bulkTarget, 0, len(res.Deployments))
+	for _, d := range res.Deployments {
+		targets = append(targets, bulkTarget{ID: *d.ID, Name: *d.Name})
+	}
+
+	return targets, nil
+}
+
+// readBulkTargets reads whitespace separated deployment IDs from the reader,
+// ignoring any duplicates.
+func readBulkTargets(r io.Reader) ([]bulkTarget, error) {
+	var targets []bulkTarget
+	var seen = make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		id := scanner.Text()
+		if len(id) != 32 {
+			return nil, fmt.Errorf(`invalid deployment ID "%s": must have a length of 32 characters`, id)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		targets = append(targets, bulkTarget{ID: id})
+	}
+
+	return targets, scanner.Err()
+}
+
+// runBulk runs the action against all the targets with the specified
+// concurrency, writing the progress of each target to the writer as it
+// finishes. The returned results keep the order of the targets.
+func runBulk(targets []bulkTarget, concurrency int, w io.Writer, action bulkAction) bulkSummary {
+	var summary = bulkSummary{Results: make([]bulkResult, len(targets))}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var finished int
+	var sem = make(chan struct{}, concurrency)
+
+	for i, t := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, t bulkTarget) {
+			defer func() { <-sem; wg.Done() }()
+
+			var result = bulkResult{ID: t.ID, Name: t.Name}
+			if err := action(t.ID); err != nil {
+				// Multi-line errors are flattened so they fit in the summary.
+				result.Error = strings.Join(strings.Fields(err.Error()), " ")
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			finished++
+			summary.Results[i] = result
+			if result.Error != "" {
+				summary.Failed++
+				_, _ = fmt.Fprintf(w, "[%d/%d] %s: failed: %s\n", finished, len(targets), t.ID, result.Error)
+				return
+			}
+			summary.Succeeded++
+			_, _ = fmt.Fprintf(w, "[%d/%d] %s: succeeded\n", finished, len(targets), t.ID)
+		}(i, t)
+	}
+	wg.Wait()
+
+	return summary
+}
+
+// restartResource restarts the specified deployment resource. If no RefID is
+// specified, it is auto-discovered.
+func restartResource(params depresourceapi.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if params.Kind == "elasticsearch" {
+		return api.ReturnErrOnly(
+			params.V1API.Deployments.RestartDeploymentEsResource(
+				deployments.NewRestartDeploymentEsResourceParams().
+					WithDeploymentID(params.DeploymentID).
+					WithRefID(params.RefID),
+				params.AuthWriter,
+			),
+		)
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.Deployments.RestartDeploymentStatelessResource(
+			deployments.NewRestartDeploymentStatelessResourceParams().
+				WithDeploymentID(params.DeploymentID).
+				WithStatelessResourceKind(params.Kind).
+				WithRefID(params.RefID),
+			params.AuthWriter,
+		),
+	)
+}
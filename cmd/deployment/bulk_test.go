@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readBulkTargets(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []bulkTarget
+		err   string
+	}{
+		{
+			name: "reads IDs separated by spaces and new lines, ignoring duplicates",
+			input: "5c17ad7c8df73206baa54b6e2829d9bc 1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10\n" +
+				"5c17ad7c8df73206baa54b6e2829d9bc\n",
+			want: []bulkTarget{
+				{ID: "5c17ad7c8df73206baa54b6e2829d9bc"},
+				{ID: "1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10"},
+			},
+		},
+		{
+			name:  "returns no targets on empty input",
+			input: "\n",
+		},
+		{
+			name:  "fails on an invalid ID",
+			input: "5c17ad7c8df73206baa54b6e2829d9bc invalid",
+			err:   `invalid deployment ID "invalid": must have a length of 32 characters`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readBulkTargets(strings.NewReader(tt.input))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_runBulk(t *testing.T) {
+	targets := []bulkTarget{
+		{ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "first"},
+		{ID: "1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10", Name: "second"},
+		{ID: "0f180c162ba34bc59a8f6fe44274f153", Name: "third"},
+	}
+
+	var running, maxRunning int32
+	var out bytes.Buffer
+	got := runBulk(targets, 2, &out, func(id string) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		if id == "1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10" {
+			return errors.New("1 error occurred:\n\t* some failure\n\n")
+		}
+		return nil
+	})
+
+	assert.LessOrEqual(t, maxRunning, int32(2))
+	assert.Equal(t, bulkSummary{
+		Succeeded: 2,
+		Failed:    1,
+		Results: []bulkResult{
+			{ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "first"},
+			{ID: "1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10", Name: "second", Error: "1 error occurred: * some failure"},
+			{ID: "0f180c162ba34bc59a8f6fe44274f153", Name: "third"},
+		},
+	}, got)
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+	assert.Contains(t, out.String(), "1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10: failed: 1 error occurred: * some failure")
+}
@@ -0,0 +1,277 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+// latestSnapshotName is the special snapshot name which the API resolves to
+// the latest successful snapshot of the source cluster.
+const latestSnapshotName = "__latest_success__"
+
+const cloneLong = `Creates a new deployment using the current configuration of an existing deployment as
+its definition. Resource IDs, credentials, trust settings and any other instance specific
+fields are removed from the generated payload, so the cloned deployment is independent
+of the source.
+
+Use --restore-snapshot to restore a snapshot from the source Elasticsearch cluster into
+the new deployment, either the latest successful snapshot ("latest") or a named one.
+When --version is specified, all the resources are created with that version, which
+can't be lower than the source version when restoring a snapshot.
+
+The deployment is created in the same region as the source, unless the global --region
+flag is explicitly specified.`
+
+const cloneExample = `## Clone a deployment restoring the latest successful snapshot and wait until it's created.
+$ ecctl deployment clone 5c17ad7c8df73206baa54b6e2829d9bc --name production-repro --restore-snapshot latest --track
+
+## Clone a deployment to a newer version, restoring a specific snapshot.
+$ ecctl deployment clone 5c17ad7c8df73206baa54b6e2829d9bc --name upgrade-test --version 8.15.0 --restore-snapshot cloud-snapshot-2024.01.01-abcdef
+
+## Obtain the payload which would be used to create the clone, without creating it.
+$ ecctl deployment clone 5c17ad7c8df73206baa54b6e2829d9bc --name production-repro --generate-payload > clone.json`
+
+var cloneCmd = &cobra.Command{
+	Use:     "clone <source deployment id> --name <name> [--restore-snapshot latest|<snapshot name>]",
+	Short:   "Creates a copy of an existing deployment",
+	Long:    cloneLong,
+	Example: cloneExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		version, _ := cmd.Flags().GetString("version")
+		snapshot, _ := cmd.Flags().GetString("restore-snapshot")
+		generatePayload, _ := cmd.Flags().GetBool("generate-payload")
+		track, _ := cmd.Flags().GetBool("track")
+
+		source, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:      true,
+				ShowSettings:   true,
+				ShowMetadata:   true,
+				ClearTransient: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		payload := newClonePayload(source)
+		if snapshot != "" {
+			if err := setCloneRestoreSnapshot(payload, source, snapshot, version); err != nil {
+				return err
+			}
+		}
+
+		// Only move the clone to another region when the region has been
+		// explicitly set, otherwise the source resources' region is kept.
+		var region string
+		if f := cmd.Flag("region"); f != nil && f.Changed {
+			region = ecctl.Get().Config.Region
+			clearPayloadRegions(payload)
+		}
+
+		if err := deploymentapi.OverrideCreateOrUpdateRequest(payload,
+			&deploymentapi.PayloadOverrides{
+				Name:               name,
+				Version:            version,
+				Region:             region,
+				ElasticsearchRefID: "main-elasticsearch",
+				OverrideRefIDs:     true,
+			},
+		); err != nil {
+			return err
+		}
+
+		if generatePayload {
+			return ecctl.Get().Formatter.Format("", payload)
+		}
+
+		reqID, _ := cmd.Flags().GetString("request-id")
+		reqID = deploymentapi.RequestID(reqID)
+		res, err := deploymentapi.Create(deploymentapi.CreateParams{
+			API:       ecctl.Get().API,
+			RequestID: reqID,
+			Request:   payload,
+		})
+		if err != nil {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(),
+				"The deployment clone returned with an error. Use the displayed request ID to recreate the deployment resources",
+			)
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Request ID:", reqID)
+			return err
+		}
+
+		return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+			App:          ecctl.Get(),
+			DeploymentID: *res.ID,
+			Track:        track,
+			Response:     res,
+		}))
+	},
+}
+
+func init() {
+	initCloneFlags()
+}
+
+func initCloneFlags() {
+	Command.AddCommand(cloneCmd)
+	cloneCmd.Flags().String("name", "", "Name for the new deployment")
+	cloneCmd.MarkFlagRequired("name")
+	cloneCmd.Flags().String("version", "", "Optional version to use for all the cloned resources, if not specified, the source versions are kept")
+	cloneCmd.Flags().String("restore-snapshot", "", `Optional snapshot from the source Elasticsearch cluster to restore, either "latest" or a snapshot name`)
+	cloneCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	cloneCmd.Flags().Bool("generate-payload", false, "Returns the deployment payload without actually creating the deployment resources")
+	cloneCmd.Flags().String("request-id", "", "Optional request ID - Can be found in the Stderr device when a previous deployment clone failed")
+}
+
+// newClonePayload builds a DeploymentCreateRequest from the source deployment's
+// current plans and settings, removing any fields which are tied to the source
+// deployment instances.
+func newClonePayload(source *models.DeploymentGetResponse) *models.DeploymentCreateRequest {
+	update := deploymentapi.NewUpdateRequest(source)
+	payload := models.DeploymentCreateRequest{
+		Name: update.Name,
+		Resources: &models.DeploymentCreateResources{
+			Apm:                update.Resources.Apm,
+			Appsearch:          update.Resources.Appsearch,
+			Elasticsearch:      update.Resources.Elasticsearch,
+			EnterpriseSearch:   update.Resources.EnterpriseSearch,
+			IntegrationsServer: update.Resources.IntegrationsServer,
+			Kibana:             update.Resources.Kibana,
+		},
+	}
+
+	if source.Settings != nil && (source.Settings.Observability != nil || source.Settings.TrafficFilterSettings != nil) {
+		payload.Settings = &models.DeploymentCreateSettings{
+			Observability:         source.Settings.Observability,
+			TrafficFilterSettings: source.Settings.TrafficFilterSettings,
+		}
+	}
+
+	if source.Metadata != nil && len(source.Metadata.Tags) > 0 {
+		payload.Metadata = &models.DeploymentCreateMetadata{
+			Tags: source.Metadata.Tags,
+		}
+	}
+
+	for _, r := range payload.Resources.Elasticsearch {
+		r.Plan.Transient = nil
+		if r.Settings != nil {
+			r.Settings.Trust = nil
+			r.Settings.KeystoreContents = nil
+		}
+	}
+	for _, r := range payload.Resources.Kibana {
+		r.Plan.Transient = nil
+	}
+	for _, r := range payload.Resources.Apm {
+		r.Plan.Transient = nil
+		if r.Plan.Apm != nil && r.Plan.Apm.SystemSettings != nil {
+			r.Plan.Apm.SystemSettings.SecretToken = ""
+		}
+	}
+	for _, r := range payload.Resources.IntegrationsServer {
+		r.Plan.Transient = nil
+	}
+	for _, r := range payload.Resources.Appsearch {
+		r.Plan.Transient = nil
+	}
+	for _, r := range payload.Resources.EnterpriseSearch {
+		r.Plan.Transient = nil
+	}
+
+	return &payload
+}
+
+// setCloneRestoreSnapshot configures the clone's Elasticsearch plan to restore
+// the specified snapshot from the source Elasticsearch cluster. When a version
+// is specified, it must not be lower than the source cluster version since
+// snapshots can't be restored into older versions.
+func setCloneRestoreSnapshot(payload *models.DeploymentCreateRequest, source *models.DeploymentGetResponse, snapshot, version string) error {
+	if len(payload.Resources.Elasticsearch) == 0 || len(source.Resources.Elasticsearch) == 0 {
+		return errors.New("cannot restore a snapshot: the source deployment has no Elasticsearch resource")
+	}
+
+	es := payload.Resources.Elasticsearch[0]
+	if version != "" && es.Plan.Elasticsearch != nil && es.Plan.Elasticsearch.Version != "" {
+		target, err := semver.Parse(version)
+		if err != nil {
+			return fmt.Errorf("failed to parse version: %v", err)
+		}
+		current, err := semver.Parse(es.Plan.Elasticsearch.Version)
+		if err != nil {
+			return fmt.Errorf("failed to parse source version: %v", err)
+		}
+		if target.LT(current) {
+			return fmt.Errorf("cannot restore a snapshot from version %s into a lower version %s", current, target)
+		}
+	}
+
+	if snapshot == "latest" {
+		snapshot = latestSnapshotName
+	}
+
+	es.Plan.Transient = &models.TransientElasticsearchPlanConfiguration{
+		RestoreSnapshot: &models.RestoreSnapshotConfiguration{
+			SourceClusterID: *source.Resources.Elasticsearch[0].ID,
+			SnapshotName:    ec.String(snapshot),
+		},
+	}
+
+	return nil
+}
+
+// clearPayloadRegions removes the region of each of the payload's resources,
+// so they can be overridden.
+func clearPayloadRegions(payload *models.DeploymentCreateRequest) {
+	for _, r := range payload.Resources.Elasticsearch {
+		r.Region = nil
+	}
+	for _, r := range payload.Resources.Kibana {
+		r.Region = nil
+	}
+	for _, r := range payload.Resources.Apm {
+		r.Region = nil
+	}
+	for _, r := range payload.Resources.IntegrationsServer {
+		r.Region = nil
+	}
+	for _, r := range payload.Resources.Appsearch {
+		r.Region = nil
+	}
+	for _, r := range payload.Resources.EnterpriseSearch {
+		r.Region = nil
+	}
+}
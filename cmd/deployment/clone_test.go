@@ -0,0 +1,198 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func newCloneSource(t *testing.T) *models.DeploymentGetResponse {
+	var source models.DeploymentGetResponse
+	require.NoError(t, source.UnmarshalBinary(showApmResp))
+	return &source
+}
+
+func Test_newClonePayload(t *testing.T) {
+	source := newCloneSource(t)
+	source.Metadata.Tags = []*models.MetadataItem{
+		{Key: ec.String("team"), Value: ec.String("search")},
+	}
+
+	got := newClonePayload(source)
+
+	assert.Equal(t, "marc-testing", got.Name)
+	assert.Equal(t, source.Metadata.Tags, got.Metadata.Tags)
+	require.NotNil(t, got.Settings)
+	assert.NotNil(t, got.Settings.Observability)
+
+	require.Len(t, got.Resources.Elasticsearch, 1)
+	es := got.Resources.Elasticsearch[0]
+	assert.Nil(t, es.Plan.Transient)
+	assert.Nil(t, es.Settings.Metadata)
+	assert.Equal(t, "gcp-asia-east1", *es.Region)
+
+	require.Len(t, got.Resources.Kibana, 1)
+	assert.Nil(t, got.Resources.Kibana[0].Plan.Transient)
+
+	require.Len(t, got.Resources.Apm, 1)
+	apm := got.Resources.Apm[0]
+	assert.Nil(t, apm.Plan.Transient)
+	assert.Empty(t, apm.Plan.Apm.SystemSettings.SecretToken)
+
+	clearPayloadRegions(got)
+	assert.Nil(t, got.Resources.Elasticsearch[0].Region)
+	assert.Nil(t, got.Resources.Kibana[0].Region)
+	assert.Nil(t, got.Resources.Apm[0].Region)
+}
+
+func Test_setCloneRestoreSnapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot string
+		version  string
+		want     *models.RestoreSnapshotConfiguration
+		err      string
+	}{
+		{
+			name:     "restores the latest snapshot",
+			snapshot: "latest",
+			want: &models.RestoreSnapshotConfiguration{
+				SourceClusterID: "0f180c162ba34bc59a8f6fe44274f153",
+				SnapshotName:    ec.String("__latest_success__"),
+			},
+		},
+		{
+			name:     "restores a named snapshot into a newer version",
+			snapshot: "my-snapshot",
+			version:  "8.0.0",
+			want: &models.RestoreSnapshotConfiguration{
+				SourceClusterID: "0f180c162ba34bc59a8f6fe44274f153",
+				SnapshotName:    ec.String("my-snapshot"),
+			},
+		},
+		{
+			name:     "fails restoring into a lower version",
+			snapshot: "latest",
+			version:  "6.0.0",
+			err:      "cannot restore a snapshot from version 7.8.0 into a lower version 6.0.0",
+		},
+		{
+			name:     "fails with an invalid version",
+			snapshot: "latest",
+			version:  "invalid",
+			err:      "failed to parse version: No Major.Minor.Patch elements found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newCloneSource(t)
+			payload := newClonePayload(source)
+			err := setCloneRestoreSnapshot(payload, source, tt.snapshot, tt.version)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, payload.Resources.Elasticsearch[0].Plan.Transient.RestoreSnapshot)
+		})
+	}
+
+	t.Run("fails when there is no Elasticsearch resource", func(t *testing.T) {
+		source := newCloneSource(t)
+		payload := newClonePayload(source)
+		payload.Resources.Elasticsearch = nil
+		assert.EqualError(t, setCloneRestoreSnapshot(payload, source, "latest", ""),
+			"cannot restore a snapshot: the source deployment has no Elasticsearch resource",
+		)
+	})
+}
+
+func Test_cloneCmd(t *testing.T) {
+	var createResponse = models.DeploymentCreateResponse{
+		Created: ec.Bool(true),
+		ID:      ec.String("f1d329b0fb34470ba8b18361cabdd2bc"),
+		Name:    ec.String("my-clone"),
+	}
+	createResponseBytes, err := json.MarshalIndent(createResponse, "", "  ")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "fails due to missing argument",
+			args: testutils.Args{
+				Cmd:  cloneCmd,
+				Args: []string{"clone", "--name", "my-clone"},
+			},
+			want: testutils.Assertion{
+				Err: "requires at least 1 arg(s), only received 0",
+			},
+		},
+		{
+			name: "fails due to API error",
+			args: testutils.Args{
+				Cmd: cloneCmd,
+				Args: []string{
+					"clone", "12357180d4e74b3d807cf7843fa6df1b", "--name", "my-clone",
+				},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					mock.SampleInternalError(),
+				}},
+			},
+			want: testutils.Assertion{
+				Err: mock.MultierrorInternalError.Error(),
+			},
+		},
+		{
+			name: "succeeds",
+			args: testutils.Args{
+				Cmd: cloneCmd,
+				Args: []string{
+					"clone", "12357180d4e74b3d807cf7843fa6df1b", "--name", "my-clone",
+					"--restore-snapshot", "latest",
+				},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					mock.New200Response(mock.NewByteBody(showApmResp)),
+					mock.New201Response(mock.NewStructBody(createResponse)),
+				}},
+			},
+			want: testutils.Assertion{
+				Stdout: string(createResponseBytes) + "\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initCloneFlags()
+		})
+	}
+}
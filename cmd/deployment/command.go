@@ -22,6 +22,7 @@ import (
 
 	cmdelasticsearch "github.com/elastic/ecctl/cmd/deployment/elasticsearch"
 	cmddeploymentextension "github.com/elastic/ecctl/cmd/deployment/extension"
+	cmdkibana "github.com/elastic/ecctl/cmd/deployment/kibana"
 	cmddeploymentplan "github.com/elastic/ecctl/cmd/deployment/plan"
 	cmddeploymentresource "github.com/elastic/ecctl/cmd/deployment/resource"
 	cmddeploymenttemplate "github.com/elastic/ecctl/cmd/deployment/template"
@@ -46,5 +47,6 @@ func init() {
 		cmddeploymenttemplate.Command,
 		cmddeploymenttrafficfilter.Command,
 		cmdelasticsearch.Command,
+		cmdkibana.Command,
 	)
 }
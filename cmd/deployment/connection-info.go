@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/connectioninfo"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const connectionInfoExample = `## Write the deployment endpoints and cloud ID to a .env file.
+$ ecctl deployment connection-info 5c17ad7c8df73206baa54b6e2829d9bc > .env
+
+## Export the deployment endpoints and cloud ID to the current shell.
+$ eval "$(ecctl deployment connection-info 5c17ad7c8df73206baa54b6e2829d9bc --as env)"
+
+## Create a Kubernetes Secret with the deployment endpoints and cloud ID.
+$ ecctl deployment connection-info 5c17ad7c8df73206baa54b6e2829d9bc --as secret --namespace apps | kubectl apply -f -`
+
+var connectionInfoCmd = &cobra.Command{
+	Use:     "connection-info <deployment-id>",
+	Short:   "Prints the deployment endpoints, ports and cloud ID in application friendly formats",
+	Example: connectionInfoExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+		})
+		if err != nil {
+			return err
+		}
+
+		info, err := connectioninfo.NewFromDeployment(res)
+		if err != nil {
+			return err
+		}
+
+		return info.Write(ecctl.Get().Config.OutputDevice, cmdutil.GetConnectionInfoWriteParams(cmd))
+	},
+}
+
+func init() {
+	initConnectionInfoFlags()
+}
+
+func initConnectionInfoFlags() {
+	Command.AddCommand(connectionInfoCmd)
+	cmdutil.AddConnectionInfoFlags(connectionInfoCmd)
+}
@@ -0,0 +1,236 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_connectionInfoCmd(t *testing.T) {
+	const id = "5c17ad7c8df73206baa54b6e2829d9bc"
+	newResponse := func() mock.Response {
+		return mock.New200StructResponse(models.DeploymentGetResponse{
+			ID:   ec.String(id),
+			Name: ec.String("My Deployment"),
+			Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID: ec.String("main-elasticsearch"),
+					Info: &models.ElasticsearchClusterInfo{Metadata: &models.ClusterMetadataInfo{
+						CloudID:    "my-deployment:ZXUtd2VzdC0x",
+						Endpoint:   "3531aaf988594efa.us-east-1.aws.found.io",
+						AliasedURL: "https://my-deployment.es.us-east-1.aws.found.io",
+						ServiceURL: "https://3531aaf988594efa.us-east-1.aws.found.io",
+						Ports:      &models.ClusterMetadataPortInfo{HTTPS: ec.Int32(9243)},
+					}},
+				}},
+				Kibana: []*models.KibanaResourceInfo{{
+					RefID: ec.String("main-kibana"),
+					Info: &models.KibanaClusterInfo{Metadata: &models.ClusterMetadataInfo{
+						ServiceURL: "https://46fa4c8a5a36a58e.us-east-1.aws.found.io:443",
+					}},
+				}},
+				IntegrationsServer: []*models.IntegrationsServerResourceInfo{{
+					RefID: ec.String("main-integrations_server"),
+					Info: &models.IntegrationsServerInfo{Metadata: &models.ClusterMetadataInfo{
+						ServiceURL: "https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io",
+						ServicesUrls: []*models.ServiceURL{
+							{Service: ec.String("apm"), URL: ec.String("https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io")},
+							{Service: ec.String("fleet"), URL: ec.String("https://8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io")},
+						},
+					}},
+				}},
+			},
+		})
+	}
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "prints the connection info in the .env format",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", id},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newResponse()}},
+			},
+			want: testutils.Assertion{
+				Stdout: "ELASTIC_CLOUD_ID='my-deployment:ZXUtd2VzdC0x'\n" +
+					"ELASTICSEARCH_URL='https://my-deployment.es.us-east-1.aws.found.io'\n" +
+					"ELASTICSEARCH_HOST='my-deployment.es.us-east-1.aws.found.io'\n" +
+					"ELASTICSEARCH_PORT='9243'\n" +
+					"KIBANA_URL='https://46fa4c8a5a36a58e.us-east-1.aws.found.io:443'\n" +
+					"KIBANA_HOST='46fa4c8a5a36a58e.us-east-1.aws.found.io'\n" +
+					"KIBANA_PORT='443'\n" +
+					"INTEGRATIONS_SERVER_URL='https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"INTEGRATIONS_SERVER_HOST='8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"INTEGRATIONS_SERVER_PORT='443'\n" +
+					"APM_URL='https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"APM_HOST='8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"APM_PORT='443'\n" +
+					"FLEET_URL='https://8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io'\n" +
+					"FLEET_HOST='8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io'\n" +
+					"FLEET_PORT='443'\n",
+			},
+		},
+		{
+			name: "prints the connection info as shell exports",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", id, "--as", "env"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newResponse()}},
+			},
+			want: testutils.Assertion{
+				Stdout: "export ELASTIC_CLOUD_ID='my-deployment:ZXUtd2VzdC0x'\n" +
+					"export ELASTICSEARCH_URL='https://my-deployment.es.us-east-1.aws.found.io'\n" +
+					"export ELASTICSEARCH_HOST='my-deployment.es.us-east-1.aws.found.io'\n" +
+					"export ELASTICSEARCH_PORT='9243'\n" +
+					"export KIBANA_URL='https://46fa4c8a5a36a58e.us-east-1.aws.found.io:443'\n" +
+					"export KIBANA_HOST='46fa4c8a5a36a58e.us-east-1.aws.found.io'\n" +
+					"export KIBANA_PORT='443'\n" +
+					"export INTEGRATIONS_SERVER_URL='https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"export INTEGRATIONS_SERVER_HOST='8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"export INTEGRATIONS_SERVER_PORT='443'\n" +
+					"export APM_URL='https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"export APM_HOST='8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io'\n" +
+					"export APM_PORT='443'\n" +
+					"export FLEET_URL='https://8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io'\n" +
+					"export FLEET_HOST='8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io'\n" +
+					"export FLEET_PORT='443'\n",
+			},
+		},
+		{
+			name: "quotes the values which contain spaces and shell metacharacters",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", id, "--as", "env"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{mock.New200StructResponse(models.DeploymentGetResponse{
+					ID:   ec.String(id),
+					Name: ec.String("my prod $(touch pwned) it's"),
+					Resources: &models.DeploymentResources{
+						Elasticsearch: []*models.ElasticsearchResourceInfo{{
+							RefID: ec.String("main-elasticsearch"),
+							Info: &models.ElasticsearchClusterInfo{Metadata: &models.ClusterMetadataInfo{
+								CloudID: "my prod $(touch pwned) it's:ZXUtd2VzdC0x",
+							}},
+						}},
+					},
+				})}},
+			},
+			want: testutils.Assertion{
+				Stdout: "export ELASTIC_CLOUD_ID='my prod $(touch pwned) it'\\''s:ZXUtd2VzdC0x'\n",
+			},
+		},
+		{
+			name: "prints the connection info as JSON",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", id, "--as", "json"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newResponse()}},
+			},
+			want: testutils.Assertion{
+				Stdout: `{
+  "id": "` + id + `",
+  "name": "My Deployment",
+  "cloud_id": "my-deployment:ZXUtd2VzdC0x",
+  "endpoints": [
+    {
+      "service": "elasticsearch",
+      "url": "https://my-deployment.es.us-east-1.aws.found.io",
+      "host": "my-deployment.es.us-east-1.aws.found.io",
+      "port": 9243
+    },
+    {
+      "service": "kibana",
+      "url": "https://46fa4c8a5a36a58e.us-east-1.aws.found.io:443",
+      "host": "46fa4c8a5a36a58e.us-east-1.aws.found.io",
+      "port": 443
+    },
+    {
+      "service": "integrations_server",
+      "url": "https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io",
+      "host": "8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io",
+      "port": 443
+    },
+    {
+      "service": "apm",
+      "url": "https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io",
+      "host": "8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io",
+      "port": 443
+    },
+    {
+      "service": "fleet",
+      "url": "https://8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io",
+      "host": "8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io",
+      "port": 443
+    }
+  ]
+}
+`,
+			},
+		},
+		{
+			name: "prints the connection info as a Kubernetes Secret",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", id, "--as", "secret", "--namespace", "apps"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newResponse()}},
+			},
+			want: testutils.Assertion{
+				Stdout: `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-deployment
+  namespace: apps
+type: Opaque
+stringData:
+  APM_HOST: 8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io
+  APM_PORT: "443"
+  APM_URL: https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io
+  ELASTIC_CLOUD_ID: my-deployment:ZXUtd2VzdC0x
+  ELASTICSEARCH_HOST: my-deployment.es.us-east-1.aws.found.io
+  ELASTICSEARCH_PORT: "9243"
+  ELASTICSEARCH_URL: https://my-deployment.es.us-east-1.aws.found.io
+  FLEET_HOST: 8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io
+  FLEET_PORT: "443"
+  FLEET_URL: https://8ac1c1e3b5d14e5c.fleet.us-east-1.aws.found.io
+  INTEGRATIONS_SERVER_HOST: 8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io
+  INTEGRATIONS_SERVER_PORT: "443"
+  INTEGRATIONS_SERVER_URL: https://8ac1c1e3b5d14e5c.apm.us-east-1.aws.found.io
+  KIBANA_HOST: 46fa4c8a5a36a58e.us-east-1.aws.found.io
+  KIBANA_PORT: "443"
+  KIBANA_URL: https://46fa4c8a5a36a58e.us-east-1.aws.found.io:443
+`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initConnectionInfoFlags()
+		})
+	}
+}
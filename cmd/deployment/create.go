@@ -36,7 +36,7 @@ import (
 )
 
 var createCmd = &cobra.Command{
-	Use:     "create {--file | --es-size <int> --es-zones <int> | --es-node-topology <obj>}",
+	Use:     "create {--file | [--deployment-template <id>] [--tier <id> --size <size> --zones <count>] [--kibana-size <size>] [--without-kibana]}",
 	Short:   "Creates a deployment",
 	PreRunE: cobra.NoArgs,
 	Long:    createLong,
@@ -120,6 +120,10 @@ func initFlags() {
 	createCmd.Flags().Bool("generate-payload", false, "Returns the deployment payload without actually creating the deployment resources")
 	createCmd.Flags().String("request-id", "", "Optional request ID - Can be found in the Stderr device when a previous deployment creation failed. For more information see the examples in the help command page")
 	createCmd.Flags().Bool("minimum-size", false, "Shrink each Elasticsearch topology element to its minimum allowed size")
+	createCmd.Flags().Bool("without-kibana", false, "Creates the deployment without the Kibana resource")
+	createCmd.Flags().Bool("without-integrations-server", false, "Creates the deployment without the Integrations Server resource")
+	createCmd.Flags().Bool("without-enterprise-search", false, "Creates the deployment without the Enterprise Search resource")
+	addTopologyFlags(createCmd)
 }
 
 func getLatestStackVersion(region string) (string, error) {
@@ -185,6 +189,11 @@ func isLegacyTemplate(t *models.DeploymentTemplateInfoV2) bool {
 func newCreatePayload(cmd *cobra.Command, version, region string) (*models.DeploymentCreateRequest, error) {
 	file, _ := cmd.Flags().GetString("file")
 	dt, _ := cmd.Flags().GetString("deployment-template")
+	topology, err := parseTopologyFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	var payload models.DeploymentCreateRequest
 	if file != "" {
 		if err := sdkcmdutil.DecodeDefinition(cmd, "file", &payload); err != nil {
@@ -193,11 +202,16 @@ func newCreatePayload(cmd *cobra.Command, version, region string) (*models.Deplo
 				errors.New("could not read the specified file, please make sure it exists"),
 			)
 		}
+		if err := removeWithoutResources(cmd, &payload, topology); err != nil {
+			return nil, err
+		}
+		if err := applyCreateTopologyFlags(&payload, topology, nil); err != nil {
+			return nil, err
+		}
 		return &payload, nil
 	}
 
 	if dt == "" {
-		var err error
 		dt, err = getDefaultTemplate(region, version)
 		if err != nil {
 			return nil, err
@@ -226,12 +240,69 @@ func newCreatePayload(cmd *cobra.Command, version, region string) (*models.Deplo
 	if err != nil {
 		return nil, err
 	}
+	if err := removeWithoutResources(cmd, result, topology); err != nil {
+		return nil, err
+	}
 	if minimumSize, _ := cmd.Flags().GetBool("minimum-size"); minimumSize {
 		shrinkTopologySizesToMinimum(result)
 	}
+	if err := applyCreateTopologyFlags(result, topology, tpl); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+// applyCreateTopologyFlags applies the topology flags to the create payload,
+// validating them against the deployment template when specified.
+func applyCreateTopologyFlags(payload *models.DeploymentCreateRequest, flags topologyFlags, tpl *models.DeploymentTemplateInfoV2) error {
+	if !flags.changed() {
+		return nil
+	}
+
+	if payload.Resources == nil {
+		return errors.New("the deployment payload has no resources")
+	}
+
+	return applyTopologyFlags(flags, newTopologyConstraints(tpl), topologyResources{
+		elasticsearch:      payload.Resources.Elasticsearch,
+		kibana:             payload.Resources.Kibana,
+		integrationsServer: payload.Resources.IntegrationsServer,
+		enterpriseSearch:   payload.Resources.EnterpriseSearch,
+	})
+}
+
+// removeWithoutResources removes the resources excluded by the --without-*
+// flags from the payload. It fails when a resource is both excluded and sized.
+func removeWithoutResources(cmd *cobra.Command, payload *models.DeploymentCreateRequest, flags topologyFlags) error {
+	if payload.Resources == nil {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("invalid resource flags")
+	if without, _ := cmd.Flags().GetBool("without-kibana"); without {
+		if flags.kibanaChanged() {
+			merr = merr.Append(errors.New("--without-kibana cannot be used with the --kibana-* flags"))
+		}
+		payload.Resources.Kibana = nil
+	}
+
+	if without, _ := cmd.Flags().GetBool("without-integrations-server"); without {
+		if flags.integrationsServerChanged() {
+			merr = merr.Append(errors.New("--without-integrations-server cannot be used with the --integrations-server-* flags"))
+		}
+		payload.Resources.IntegrationsServer = nil
+	}
+
+	if without, _ := cmd.Flags().GetBool("without-enterprise-search"); without {
+		if flags.enterpriseSearchChanged() {
+			merr = merr.Append(errors.New("--without-enterprise-search cannot be used with the --enterprise-search-* flags"))
+		}
+		payload.Resources.EnterpriseSearch = nil
+	}
+
+	return merr.ErrorOrNil()
+}
+
 func removeUnsupportedResources(version string, tpl *models.DeploymentCreateRequest) (*models.DeploymentCreateRequest, error) {
 	vers, err := semver.Parse(version)
 	if err != nil {
@@ -31,6 +31,12 @@ These are the available options:
   * File definition: --file=<file path> (shorthand: -f). You can create a definition by using the sample JSON seen here:
     https://elastic.co/guide/en/cloud/current/ec-api-deployment-crud.html#ec_create_a_deployment
 
+The size and zone count of an Elasticsearch tier can be changed with --tier, --size, --zones and --autoscale-max,
+and the Kibana, Integrations Server and Enterprise Search ones with --kibana-size, --kibana-zones,
+--integrations-server-size, --integrations-server-zones, --enterprise-search-size and --enterprise-search-zones.
+The sizes are validated against the deployment template. Resources included in the deployment template can be left
+out with --without-kibana, --without-integrations-server and --without-enterprise-search.
+
 As an option "--generate-payload" can be used in order to obtain the generated payload that would be sent as a request. 
 Save it, update or extend the topology and create a deployment using the saved payload with the "--file" flag.`
 
@@ -45,6 +51,12 @@ $ ecctl deployment create --name my-deployment --deployment-template=aws-io-opti
 Deployment [b6ecbea3d5c84124b7dca457f2892086] - [Elasticsearch][b6ecbea3d5c84124b7dca457f2892086]: finished running all the plan steps (Total plan duration: 5m11.s)
 Deployment [91c4d60acb804ba0a27651fac02780ec] - [Kibana][8a9d9916cd6e46a7bb0912211d76e2af]: finished running all the plan steps (Total plan duration: 4m29.58s)
 
+## Create a deployment with a hot tier of 8g per zone across 3 zones and a 2g Kibana instance.
+$ ecctl deployment create --name my-deployment --deployment-template=aws-io-optimized-v2 --tier hot_content --size 8g --zones 3 --kibana-size 2g
+
+## Create a deployment with a 4g Enterprise Search instance and without Integrations Server.
+$ ecctl deployment create --name my-deployment --deployment-template=aws-io-optimized-v2 --enterprise-search-size 4g --without-integrations-server
+
 ## In order to use the "--deployment-template" flag, you'll need to know which deployment templates ara available to you.
 You'll need to run the following command to view your deployment templates:
 $ ecctl platform deployment-template list
@@ -26,6 +26,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
@@ -939,3 +940,59 @@ func Test_shrinkTopologySizesToMinimum(t *testing.T) {
 		})
 	}
 }
+
+func Test_removeWithoutResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		flags    topologyFlags
+		expected *models.DeploymentCreateResources
+		err      string
+	}{
+		{
+			name: "keeps all the resources when no flags are set",
+			expected: &models.DeploymentCreateResources{
+				Kibana:             []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+				IntegrationsServer: []*models.IntegrationsServerPayload{{RefID: ec.String("main-integrations_server")}},
+				EnterpriseSearch:   []*models.EnterpriseSearchPayload{{RefID: ec.String("main-enterprise_search")}},
+			},
+		},
+		{
+			name: "removes the excluded resources",
+			args: []string{"--without-integrations-server", "--without-enterprise-search"},
+			expected: &models.DeploymentCreateResources{
+				Kibana: []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+			},
+		},
+		{
+			name:  "fails when an excluded resource is sized",
+			args:  []string{"--without-kibana", "--kibana-size=2g"},
+			flags: topologyFlags{kibanaSize: ec.Int32(2048)},
+			err: "invalid resource flags: 1 error occurred:\n" +
+				"\t* --without-kibana cannot be used with the --kibana-* flags\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("without-kibana", false, "")
+			cmd.Flags().Bool("without-integrations-server", false, "")
+			cmd.Flags().Bool("without-enterprise-search", false, "")
+			addTopologyFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			payload := &models.DeploymentCreateRequest{Resources: &models.DeploymentCreateResources{
+				Kibana:             []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+				IntegrationsServer: []*models.IntegrationsServerPayload{{RefID: ec.String("main-integrations_server")}},
+				EnterpriseSearch:   []*models.EnterpriseSearchPayload{{RefID: ec.String("main-enterprise_search")}},
+			}}
+			err := removeWithoutResources(cmd, payload, tt.flags)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, payload.Resources)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/go-openapi/strfmt"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const editExample = `## Edit the deployment update payload in $EDITOR and apply the changes.
+$ ecctl deployment edit 5c17ad7c8df73206baa54b6e2829d9bc
+
+## Edit the deployment update payload as YAML and track the changes.
+$ ecctl deployment edit 5c17ad7c8df73206baa54b6e2829d9bc --payload-format yaml --track`
+
+var editCmd = &cobra.Command{
+	Use:   "edit <deployment-id>",
+	Short: "Edits a deployment update payload in $EDITOR and applies the changes",
+	Long: `Opens the current deployment state, as returned by "show --generate-update-payload",
+in the editor set in $VISUAL or $EDITOR. Once saved, the edited payload is validated,
+and if it's invalid, the editor is re-opened with the errors at the top of the file.
+The diff of the changes is shown before the deployment is updated.`,
+	Example: editExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:      true,
+				ShowSettings:   true,
+				ClearTransient: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		var req *models.DeploymentUpdateRequest
+		format, _ := cmd.Flags().GetString("payload-format")
+		changed, err := cmdutil.EditPayload(cmdutil.EditPayloadParams{
+			Payload: deploymentapi.NewUpdateRequest(res),
+			Format:  format,
+			Output:  ecctl.Get().Config.OutputDevice,
+			Decode: func(data []byte) error {
+				var r models.DeploymentUpdateRequest
+				if err := cmdutil.DecodeStrict(data, &r); err != nil {
+					return err
+				}
+				if err := r.Validate(strfmt.Default); err != nil {
+					return err
+				}
+				req = &r
+				return nil
+			},
+		})
+		if err != nil || !changed {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		var msg = fmt.Sprintf("Do you want to apply the changes to deployment %s? [y/n]: ", args[0])
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		skipSnapshot, _ := cmd.Flags().GetBool("skip-snapshot")
+		updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			Request:      req,
+			SkipSnapshot: skipSnapshot,
+		})
+		if err != nil {
+			return err
+		}
+
+		var track, _ = cmd.Flags().GetBool("track")
+		return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+			App:          ecctl.Get(),
+			DeploymentID: args[0],
+			Track:        track,
+			Response:     updateRes,
+		}))
+	},
+}
+
+func init() {
+	initEditFlags()
+}
+
+func initEditFlags() {
+	Command.AddCommand(editCmd)
+	editCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	editCmd.Flags().Bool("skip-snapshot", false, "Skips taking an Elasticsearch snapshot prior to applying the edited plan")
+	editCmd.Flags().String("payload-format", cmdutil.PayloadFormatJSON, cmdutil.PayloadFormatFlagMessage)
+}
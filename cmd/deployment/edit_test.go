@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_editCmd(t *testing.T) {
+	const id = "5c17ad7c8df73206baa54b6e2829d9bc"
+	// The force flag is a persistent flag of the root command.
+	if Command.PersistentFlags().Lookup("force") == nil {
+		Command.PersistentFlags().Bool("force", false, "")
+	}
+
+	var getQuery = url.Values{
+		"clear_transient":      {"true"},
+		"convert_legacy_plans": {"false"},
+		"show_metadata":        {"false"},
+		"show_plan_defaults":   {"false"},
+		"show_plan_history":    {"false"},
+		"show_plan_logs":       {"false"},
+		"show_plans":           {"true"},
+		"show_settings":        {"true"},
+		"show_system_alerts":   {"5"},
+	}
+	newGetResponse := func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Method: "GET",
+				Path:   "/api/v1/deployments/" + id,
+				Host:   api.DefaultMockHost,
+				Query:  getQuery,
+			},
+			mock.NewStructBody(models.DeploymentGetResponse{
+				ID:   ec.String(id),
+				Name: ec.String("my-deployment"),
+				Resources: &models.DeploymentResources{
+					Elasticsearch: []*models.ElasticsearchResourceInfo{{
+						ID:     ec.String("3531aaf988594efa87c1aabb7caed337"),
+						RefID:  ec.String("main-elasticsearch"),
+						Region: ec.String("us-east-1"),
+						Info: &models.ElasticsearchClusterInfo{
+							ClusterName: ec.String("my-deployment"),
+							PlanInfo: &models.ElasticsearchClusterPlansInfo{
+								Current: &models.ElasticsearchClusterPlanInfo{
+									Plan: &models.ElasticsearchClusterPlan{
+										Elasticsearch: &models.ElasticsearchConfiguration{Version: "8.13.2"},
+										ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+											{ID: "hot_content"},
+										},
+									},
+								},
+							},
+						},
+					}},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name   string
+		editor string
+		args   testutils.Args
+		want   testutils.Assertion
+	}{
+		{
+			name:   "shows the diff and updates the deployment",
+			editor: `sed -i s/"my-deployment",/"renamed",/`,
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", id, "--force"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newGetResponse(),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/" + id,
+								Host:   api.DefaultMockHost,
+								Query: url.Values{
+									"hide_pruned_orphans": {"false"},
+									"skip_snapshot":       {"false"},
+									"validate_only":       {"false"},
+								},
+								Body: mock.NewStringBody(`{"name":"renamed","prune_orphans":false,"resources":{"apm":null,"appsearch":null,` +
+									`"elasticsearch":[{"display_name":"renamed","plan":{"cluster_topology":[{"id":"hot_content","node_roles":null}],` +
+									`"elasticsearch":{"version":"8.13.2"}},"ref_id":"main-elasticsearch","region":"us-east-1"}],` +
+									`"enterprise_search":null,"integrations_server":null,"kibana":null}}` + "\n"),
+							},
+							mock.NewStringBody(`{"id":"`+id+`"}`),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: `--- current
++++ edited
+@@ -1,12 +1,12 @@
+ {
+-  "name": "my-deployment",
++  "name": "renamed",
+   "prune_orphans": false,
+   "resources": {
+     "apm": null,
+     "appsearch": null,
+     "elasticsearch": [
+       {
+-        "display_name": "my-deployment",
++        "display_name": "renamed",
+         "plan": {
+           "cluster_topology": [
+             {
+{
+  "id": "` + id + `",
+  "name": null,
+  "resources": null
+}
+`,
+			},
+		},
+		{
+			name:   "doesn't update the deployment when the payload hasn't changed",
+			editor: "true",
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", id},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newGetResponse()}},
+			},
+			want: testutils.Assertion{Stdout: "No changes to the payload\n"},
+		},
+		{
+			name:   "fails when the edited payload is invalid",
+			editor: "sed -i /prune_orphans/d",
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", id},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newGetResponse()}},
+			},
+			want: testutils.Assertion{Err: "the edited payload is invalid: validation failure list:\nprune_orphans in body is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", "")
+			t.Setenv("EDITOR", tt.editor)
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initEditFlags()
+		})
+	}
+}
@@ -18,9 +18,12 @@
 package cmdelasticsearch
 
 import (
+	"github.com/elastic/cloud-sdk-go/pkg/util"
 	"github.com/spf13/cobra"
 
 	cmdeskeystore "github.com/elastic/ecctl/cmd/deployment/elasticsearch/keystore"
+	cmdessnapshot "github.com/elastic/ecctl/cmd/deployment/elasticsearch/snapshot"
+	cmddeploymentusersettings "github.com/elastic/ecctl/cmd/deployment/usersettings"
 )
 
 // Command is the deployment subcommand
@@ -35,4 +38,6 @@ var Command = &cobra.Command{
 
 func init() {
 	Command.AddCommand(cmdeskeystore.Command)
+	Command.AddCommand(cmdessnapshot.Command)
+	Command.AddCommand(cmddeploymentusersettings.NewCommand(util.Elasticsearch))
 }
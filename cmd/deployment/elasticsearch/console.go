@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdelasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/esproxy"
+)
+
+const consoleLong = `Sends a request to an Elasticsearch resource through the deployment's
+Elasticsearch proxy, using the configured ecctl credentials.
+
+The request body can be specified with --data, either inline, from a file when
+prefixed with @, or from the standard input with @-. JSON responses are shown
+in the configured --output format, any other response is shown as is.
+
+Only GET and HEAD requests are sent by default, since any other method can modify
+the cluster, which includes POST requests such as searches. Those requests need
+the --allow-write flag to be sent.`
+
+const consoleExample = `## Show the indices of the cluster.
+$ ecctl deployment elasticsearch console 5c17ad7c8df73206baa54b6e2829d9bc GET _cat/indices?v
+
+## Search an index with a query read from a file.
+$ ecctl deployment elasticsearch console 5c17ad7c8df73206baa54b6e2829d9bc POST logs-1/_search -d @query.json --allow-write`
+
+// readOnlyMethods are the methods which can be sent without --allow-write.
+var readOnlyMethods = map[string]bool{
+	http.MethodGet:  true,
+	http.MethodHead: true,
+}
+
+var consoleCmd = &cobra.Command{
+	Use:     "console <deployment id> <method> <path> [-d <body>|@<file>]",
+	Short:   "Sends a request to an Elasticsearch resource through the Elastic Cloud proxy",
+	Long:    consoleLong,
+	Example: consoleExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refID, _ := cmd.Flags().GetString("ref-id")
+		data, _ := cmd.Flags().GetString("data")
+		allowWrite, _ := cmd.Flags().GetBool("allow-write")
+
+		var method = strings.ToUpper(args[1])
+		if !readOnlyMethods[method] && !allowWrite {
+			return fmt.Errorf("%s requests can modify the cluster, use --allow-write to send them", method)
+		}
+
+		body, err := readConsoleBody(cmd.InOrStdin(), data)
+		if err != nil {
+			return err
+		}
+
+		res, err := esproxy.Do(esproxy.Params{
+			API:          ecctl.Get().API,
+			Host:         ecctl.Get().Config.Host,
+			Client:       ecctl.Get().Config.Client,
+			DeploymentID: args[0],
+			RefID:        refID,
+			Method:       method,
+			Path:         args[2],
+			Body:         body,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := writeConsoleResponse(ecctl.Get().Config.OutputDevice, method, res); err != nil {
+			return err
+		}
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return fmt.Errorf("elasticsearch returned status %d", res.StatusCode)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(consoleCmd)
+	initConsoleFlags()
+}
+
+func initConsoleFlags() {
+	consoleCmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+	consoleCmd.Flags().StringP("data", "d", "", "Optional request body, read from a file when prefixed with @, or from stdin with @-")
+	consoleCmd.Flags().Bool("allow-write", false, "Allows sending requests with methods other than GET and HEAD")
+}
+
+// readConsoleBody returns the request body specified by the --data flag, which
+// is read from a file when prefixed with @ or from stdin when it's @-.
+func readConsoleBody(stdin io.Reader, data string) ([]byte, error) {
+	switch {
+	case data == "":
+		return nil, nil
+	case data == "@-":
+		return io.ReadAll(stdin)
+	case strings.HasPrefix(data, "@"):
+		return os.ReadFile(strings.TrimPrefix(data, "@"))
+	default:
+		return []byte(data), nil
+	}
+}
+
+// writeConsoleResponse writes the response body, formatting JSON responses in
+// the configured output format. The status is written for HEAD requests, since
+// their responses have no body.
+func writeConsoleResponse(w io.Writer, method string, res *esproxy.Response) error {
+	if method == http.MethodHead {
+		_, err := fmt.Fprintf(w, "%d %s\n", res.StatusCode, http.StatusText(res.StatusCode))
+		return err
+	}
+
+	if len(res.Body) == 0 {
+		return nil
+	}
+
+	if json.Valid(res.Body) {
+		return ecctl.Get().Formatter.Format("", json.RawMessage(res.Body))
+	}
+
+	_, err := w.Write(res.Body)
+	return err
+}
@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdelasticsearch
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_consoleCmd(t *testing.T) {
+	const id = "320b7b540dfc967a7a649c18e2fce4ed"
+	var queryFile = filepath.Join(t.TempDir(), "query.json")
+	require.NoError(t, os.WriteFile(queryFile, []byte(`{"query":{"match_all":{}}}`), 0600))
+
+	newResponse := func(method, path, body string, status int, res string) mock.Response {
+		var headers = http.Header{
+			"Authorization":        {"ApiKey dummy"},
+			"User-Agent":           api.DefaultReadMockHeaders["User-Agent"],
+			"X-Management-Request": {"true"},
+		}
+		var reqBody = mock.NewStringBody(body)
+		if body == "" {
+			reqBody = nil
+		} else {
+			headers.Set("Content-Type", "application/json")
+		}
+		return mock.Response{
+			Response: http.Response{StatusCode: status, Body: mock.NewStringBody(res)},
+			Assert: &mock.RequestAssertion{
+				Header: headers,
+				Method: method,
+				Path:   "/api/v1/deployments/" + id + "/elasticsearch/main-elasticsearch/proxy/" + path,
+				Host:   api.DefaultMockHost,
+				Body:   reqBody,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "formats the JSON response",
+			args: testutils.Args{
+				Cmd:  consoleCmd,
+				Args: []string{"console", id, "get", "_cluster/health", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newResponse("GET", "_cluster/health", "", 200, `{"status":"green","number_of_nodes":3}`),
+				}},
+			},
+			want: testutils.Assertion{Stdout: "{\n  \"status\": \"green\",\n  \"number_of_nodes\": 3\n}\n"},
+		},
+		{
+			name: "shows a text response as is",
+			args: testutils.Args{
+				Cmd:  consoleCmd,
+				Args: []string{"console", id, "GET", "_cat/health", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newResponse("GET", "_cat/health", "", 200, "1714557600 10:00:00 my-cluster green 3 3\n"),
+				}},
+			},
+			want: testutils.Assertion{Stdout: "1714557600 10:00:00 my-cluster green 3 3\n"},
+		},
+		{
+			name: "shows the status of a HEAD request",
+			args: testutils.Args{
+				Cmd:  consoleCmd,
+				Args: []string{"console", id, "HEAD", "logs-1", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newResponse("HEAD", "logs-1", "", 404, ""),
+				}},
+			},
+			want: testutils.Assertion{
+				Stdout: "404 Not Found\n",
+				Err:    "elasticsearch returned status 404",
+			},
+		},
+		{
+			name: "refuses to send a POST request without --allow-write",
+			args: testutils.Args{
+				Cmd:  consoleCmd,
+				Args: []string{"console", id, "POST", "logs-1/_search", "-d", "@" + queryFile},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{Err: "POST requests can modify the cluster, use --allow-write to send them"},
+		},
+		{
+			name: "sends a POST request with the body read from a file",
+			args: testutils.Args{
+				Cmd:  consoleCmd,
+				Args: []string{"console", id, "POST", "logs-1/_search", "-d", "@" + queryFile, "--allow-write", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newResponse("POST", "logs-1/_search", `{"query":{"match_all":{}}}`, 200, `{"hits":{"total":{"value":0},"hits":[]}}`),
+				}},
+			},
+			want: testutils.Assertion{
+				Stdout: "{\n  \"hits\": {\n    \"total\": {\n      \"value\": 0\n    },\n    \"hits\": []\n  }\n}\n",
+			},
+		},
+		{
+			name: "shows the error response",
+			args: testutils.Args{
+				Cmd:  consoleCmd,
+				Args: []string{"console", id, "DELETE", "logs-2", "--allow-write", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newResponse("DELETE", "logs-2", "", 404, `{"error":{"type":"index_not_found_exception"},"status":404}`),
+				}},
+			},
+			want: testutils.Assertion{
+				Stdout: "{\n  \"error\": {\n    \"type\": \"index_not_found_exception\"\n  },\n  \"status\": 404\n}\n",
+				Err:    "elasticsearch returned status 404",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initConsoleFlags()
+		})
+	}
+}
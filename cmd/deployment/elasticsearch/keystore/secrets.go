@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdeskeystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+// updateSecrets changes the specified keystore secrets, removing the secrets
+// which are nil. Since the SDK keystore model can't represent the null value
+// which removes a secret, the PATCH request is sent directly with a client
+// which never writes the secret values to the verbose output.
+func updateSecrets(id, refID string, secrets map[string]*models.KeystoreSecret) (*models.KeystoreContents, error) {
+	if err := deploymentapi.PopulateRefID(deploymentapi.PopulateRefIDParams{
+		API:          ecctl.Get().API,
+		DeploymentID: id,
+		RefID:        &refID,
+		Kind:         util.Elasticsearch,
+	}); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"secrets": secrets})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/deployments/%s/elasticsearch/%s/keystore",
+		strings.TrimRight(ecctl.Get().Config.Host, "/"), id, refID,
+	)
+
+	req, err := http.NewRequest(http.MethodPatch, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req = ecctl.Get().API.AuthWriter.AuthRequest(req)
+
+	resp, err := ecctl.Get().NewNonVerboseClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var res models.KeystoreContents
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdeskeystore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const setLong = `Sets a single secret in the Elasticsearch resource keystore. To keep the value
+out of the shell history, it's read either from stdin with --value-stdin or from
+a file with --value-file, and it's never written to the --verbose output.
+
+Secrets which are files in the keystore, such as credentials files, are set with
+--as-file.
+
+Before this command was added, "set" was an alias of "update". Setting the keystore
+contents from a file with "set --file" is still supported but deprecated, use
+"update --file" instead.`
+
+const setExample = `## Set a secret read from stdin.
+$ echo -n "$SECRET" | ecctl deployment elasticsearch keystore set 5c17ad7c8df73206baa54b6e2829d9bc s3.client.default.secret_key --value-stdin
+
+## Set a credentials file.
+$ ecctl deployment elasticsearch keystore set 5c17ad7c8df73206baa54b6e2829d9bc gcs.client.default.credentials_file --value-file gcs-creds.json --as-file`
+
+const setFileDeprecation = `Flag --file is deprecated, use "ecctl deployment elasticsearch keystore update --file" instead`
+
+var setCmd = &cobra.Command{
+	Use:     "set <deployment id> <name> {--value-stdin | --value-file <path>} [--as-file]",
+	Short:   "Sets a single secret in an Elasticsearch keystore",
+	Long:    setLong,
+	Example: setExample,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flag("file").Changed {
+			return sdkcmdutil.MinimumNArgsAndUUID(1)(cmd, args)
+		}
+		return sdkcmdutil.MinimumNArgsAndUUID(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Kept for backwards compatibility with the former "set" alias of
+		// the update command.
+		if cmd.Flag("file").Changed {
+			if cmd.Flag("value-stdin").Changed || cmd.Flag("value-file").Changed || cmd.Flag("as-file").Changed {
+				return errors.New("--file cannot be used with --value-stdin, --value-file or --as-file")
+			}
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), setFileDeprecation)
+			return runUpdate(cmd, args)
+		}
+
+		refID, _ := cmd.Flags().GetString("ref-id")
+		valueStdin, _ := cmd.Flags().GetBool("value-stdin")
+		valueFile, _ := cmd.Flags().GetString("value-file")
+		asFile, _ := cmd.Flags().GetBool("as-file")
+
+		value, err := readSecretValue(cmd.InOrStdin(), valueStdin, valueFile)
+		if err != nil {
+			return err
+		}
+
+		var secret = models.KeystoreSecret{Value: value}
+		if asFile {
+			secret.AsFile = ec.Bool(true)
+		}
+
+		res, err := updateSecrets(args[0], refID, map[string]*models.KeystoreSecret{
+			args[1]: &secret,
+		})
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/eskeystore_show", res)
+	},
+}
+
+func init() {
+	Command.AddCommand(setCmd)
+	initSetFlags()
+}
+
+func initSetFlags() {
+	setCmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+	setCmd.Flags().Bool("value-stdin", false, "Reads the secret value from stdin")
+	setCmd.Flags().String("value-file", "", "Reads the secret value from the specified file")
+	setCmd.Flags().Bool("as-file", false, "Stores the secret as a file in the keystore")
+	setCmd.Flags().StringP("file", "f", "", "Json formatted file path with the keystore secret contents.")
+	setCmd.Flags().MarkHidden("file")
+}
+
+// readSecretValue reads the secret value from stdin or from the specified
+// file. A single trailing newline is removed from values read from stdin, since
+// it's usually added by the shell.
+func readSecretValue(stdin io.Reader, fromStdin bool, path string) (string, error) {
+	if fromStdin == (path != "") {
+		return "", errors.New("one of --value-stdin or --value-file must be specified")
+	}
+
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	b, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r")
+	if value == "" {
+		return "", errors.New("the secret value read from stdin is empty")
+	}
+
+	return value, nil
+}
@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdeskeystore
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+	"github.com/elastic/ecctl/pkg/util"
+)
+
+// newKeystorePatchResponse returns a response to a keystore PATCH request
+// which is sent directly with the non-verbose client.
+func newKeystorePatchResponse(body, res string) mock.Response {
+	return mock.Response{
+		Response: http.Response{StatusCode: 200, Body: mock.NewStringBody(res)},
+		Assert: &mock.RequestAssertion{
+			Header: http.Header{
+				"Accept":        {"application/json"},
+				"Authorization": {"ApiKey dummy"},
+				"Content-Type":  {"application/json"},
+			},
+			Method: "PATCH",
+			Path:   "/api/v1/deployments/" + util.ValidClusterID + "/elasticsearch/main-elasticsearch/keystore",
+			Host:   api.DefaultMockHost,
+			Body:   mock.NewStringBody(body),
+		},
+	}
+}
+
+func Test_setCmd(t *testing.T) {
+	var valueFile = filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(valueFile, []byte(`{"type":"service_account"}`), 0600))
+
+	var contents = new(models.KeystoreContents)
+	require.NoError(t, contents.UnmarshalBinary(updateRawResp))
+	updateBody, err := contents.MarshalBinary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "fails when no value is specified",
+			args: testutils.Args{
+				Cmd:  setCmd,
+				Args: []string{"set", util.ValidClusterID, "my-secret"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{Err: "one of --value-stdin or --value-file must be specified"},
+		},
+		{
+			name: "sets a file secret without writing it to the verbose output",
+			args: testutils.Args{
+				Cmd: setCmd,
+				Args: []string{"set", util.ValidClusterID, "gcs.client.default.credentials_file",
+					"--value-file", valueFile, "--as-file", "--ref-id", "main-elasticsearch",
+				},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Verbose:      true,
+					Responses: []mock.Response{newKeystorePatchResponse(
+						`{"secrets":{"gcs.client.default.credentials_file":{"as_file":true,"value":"{\"type\":\"service_account\"}"}}}`,
+						`{"secrets":{"gcs.client.default.credentials_file":{"as_file":true}}}`,
+					)},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "SECRET                                VALUE        AS FILE\n" +
+					"gcs.client.default.credentials_file   <no value>   true\n",
+			},
+		},
+		{
+			name: "updates the keystore contents from a file with the deprecated --file flag",
+			args: testutils.Args{
+				Cmd: setCmd,
+				Args: []string{"set", util.ValidClusterID, "--file=./testdata/update.json",
+					"--ref-id", "main-elasticsearch",
+				},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{mock.New200ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Method: "PATCH",
+							Path:   "/api/v1/deployments/" + util.ValidClusterID + "/elasticsearch/main-elasticsearch/keystore",
+							Host:   api.DefaultMockHost,
+							Body:   mock.NewByteBody(append(updateBody, '\n')),
+						},
+						mock.NewStringBody(`{"secrets":{"my-secret":{}}}`),
+					)},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "SECRET      VALUE        AS FILE\n" +
+					"my-secret   <no value>   <nil>\n",
+				Stderr: setFileDeprecation + "\n",
+			},
+		},
+		{
+			name: "fails when --file is used with a secret value",
+			args: testutils.Args{
+				Cmd: setCmd,
+				Args: []string{"set", util.ValidClusterID, "--file=./testdata/update.json",
+					"--value-file", valueFile,
+				},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{
+				Err: "--file cannot be used with --value-stdin, --value-file or --as-file",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initSetFlags()
+		})
+	}
+}
+
+func Test_readSecretValue(t *testing.T) {
+	value, err := readSecretValue(strings.NewReader("s3cr3t\n"), true, "")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = readSecretValue(strings.NewReader("\n"), true, "")
+	assert.EqualError(t, err, "the secret value read from stdin is empty")
+
+	_, err = readSecretValue(nil, true, "file")
+	assert.EqualError(t, err, "one of --value-stdin or --value-file must be specified")
+}
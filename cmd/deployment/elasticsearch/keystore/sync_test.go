@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdeskeystore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+	"github.com/elastic/ecctl/pkg/util"
+)
+
+func Test_parseEnvFile(t *testing.T) {
+	entries, err := parseEnvFile(strings.NewReader(`
+# A comment.
+export FIRST=one
+second = "two\nlines"
+third='single # quoted'
+FIRST=overridden
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []envEntry{
+		{Key: "FIRST", Value: "overridden"},
+		{Key: "second", Value: "two\nlines"},
+		{Key: "third", Value: "single # quoted"},
+	}, entries)
+
+	_, err = parseEnvFile(strings.NewReader("FIRST=one\ninvalid\n"))
+	assert.EqualError(t, err, "line 2: expected KEY=VALUE")
+}
+
+func Test_syncCmd(t *testing.T) {
+	var dir = t.TempDir()
+	var envFile = filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("my-secret=one\nmy-other-secret=two\n"), 0600))
+
+	newGetResponse := func(secrets string) mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Method: "GET",
+				Path:   "/api/v1/deployments/" + util.ValidClusterID + "/elasticsearch/main-elasticsearch/keystore",
+				Host:   api.DefaultMockHost,
+			},
+			mock.NewStringBody(`{"secrets":{`+secrets+`}}`),
+		)
+	}
+	var args = []string{"sync", util.ValidClusterID, "--from", envFile, "--ref-id", "main-elasticsearch"}
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "pushes the secrets missing from the keystore",
+			args: testutils.Args{
+				Cmd:  syncCmd,
+				Args: args,
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newGetResponse(`"my-secret":{"as_file":false}`),
+					newKeystorePatchResponse(`{"secrets":{"my-other-secret":{"value":"two"}}}`, `{"secrets":{}}`),
+				}},
+			},
+			want: testutils.Assertion{Stdout: "Updated 1 keystore secrets: my-other-secret\n"},
+		},
+		{
+			name: "doesn't push the secrets which are already in the keystore",
+			args: testutils.Args{
+				Cmd:  syncCmd,
+				Args: args,
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newGetResponse(`"my-secret":{"as_file":false},"my-other-secret":{"as_file":false}`),
+				}},
+			},
+			want: testutils.Assertion{Stdout: "The keystore is up to date with " + envFile + "\n"},
+		},
+		{
+			name: "pushes all the secrets with --overwrite",
+			args: testutils.Args{
+				Cmd:  syncCmd,
+				Args: append(args, "--overwrite"),
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newGetResponse(`"my-secret":{"as_file":false},"my-other-secret":{"as_file":false}`),
+					newKeystorePatchResponse(
+						`{"secrets":{"my-other-secret":{"value":"two"},"my-secret":{"value":"one"}}}`,
+						`{"secrets":{}}`,
+					),
+				}},
+			},
+			want: testutils.Assertion{Stdout: "Updated 2 keystore secrets: my-secret, my-other-secret\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initSyncFlags()
+		})
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no files other than the .env file must be written")
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdeskeystore
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+var unsetCmd = &cobra.Command{
+	Use:     "unset <deployment id> <name>...",
+	Short:   "Removes secrets from an Elasticsearch keystore",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refID, _ := cmd.Flags().GetString("ref-id")
+
+		force, _ := cmd.Flags().GetBool("force")
+		msg := fmt.Sprintf("This action will remove the %s keystore secrets. Do you want to continue? [y/n]: ",
+			strings.Join(args[1:], ", "),
+		)
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		var secrets = make(map[string]*models.KeystoreSecret, len(args[1:]))
+		for _, name := range args[1:] {
+			secrets[name] = nil
+		}
+
+		res, err := updateSecrets(args[0], refID, secrets)
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/eskeystore_show", res)
+	},
+}
+
+func init() {
+	Command.AddCommand(unsetCmd)
+	initUnsetFlags()
+}
+
+func initUnsetFlags() {
+	unsetCmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdeskeystore
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+	"github.com/elastic/ecctl/pkg/util"
+)
+
+func Test_unsetCmd(t *testing.T) {
+	// The force flag is a persistent flag of the root command.
+	if Command.PersistentFlags().Lookup("force") == nil {
+		Command.PersistentFlags().Bool("force", false, "")
+	}
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "removes the secrets by setting them to null",
+			args: testutils.Args{
+				Cmd:  unsetCmd,
+				Args: []string{"unset", util.ValidClusterID, "my-secret", "my-other-secret", "--ref-id", "main-elasticsearch", "--force"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{newKeystorePatchResponse(
+						`{"secrets":{"my-other-secret":null,"my-secret":null}}`,
+						`{"secrets":{"remaining-secret":{"as_file":false}}}`,
+					)},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "SECRET             VALUE        AS FILE\n" +
+					"remaining-secret   <no value>   false\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initUnsetFlags()
+		})
+	}
+}
@@ -61,7 +61,7 @@ $ cat gcs-creds.json
         }
     }
 }
-$ ecctl deployment elasticsearch keystore set --file=gcs-creds.json <Deployment ID>
+$ ecctl deployment elasticsearch keystore update --file=gcs-creds.json <Deployment ID>
 ...
 # Set multiple secrets in one playload
 $ cat multiple.json
@@ -75,7 +75,7 @@ $ cat multiple.json
         }
     }
 }
-$ ecctl deployment elasticsearch keystore set --file=multiple.json <Deployment ID>
+$ ecctl deployment elasticsearch keystore update --file=multiple.json <Deployment ID>
 ...
 `
 
@@ -88,31 +88,33 @@ var updateCmd = &cobra.Command{
 	Use:     "update <deployment id> [--ref-id <ref-id>] {--file=<filename>.json}",
 	Long:    updateLong,
 	Example: updateExample,
-	Aliases: []string{"set"},
 	Short:   "Updates the contents of an Elasticsearch keystore",
 	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		var contents models.KeystoreContents
-		if err := sdkcmdutil.DecodeDefinition(cmd, "file", &contents); err != nil {
-			if errors.Is(err, io.EOF) {
-				return fmt.Errorf("%s: %s", errReadingDefPrefix, errReadingDefMessage)
-			}
-			return fmt.Errorf("%s: %s", errReadingDefPrefix, err.Error())
-		}
+	RunE:    runUpdate,
+}
 
-		refID, _ := cmd.Flags().GetString("ref-id")
-		res, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
-			API:          ecctl.Get().API,
-			DeploymentID: args[0],
-			RefID:        refID,
-			Contents:     &contents,
-		})
-		if err != nil {
-			return err
+// runUpdate updates the keystore contents with the ones read from --file.
+func runUpdate(cmd *cobra.Command, args []string) error {
+	var contents models.KeystoreContents
+	if err := sdkcmdutil.DecodeDefinition(cmd, "file", &contents); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %s", errReadingDefPrefix, errReadingDefMessage)
 		}
+		return fmt.Errorf("%s: %s", errReadingDefPrefix, err.Error())
+	}
+
+	refID, _ := cmd.Flags().GetString("ref-id")
+	res, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
+		API:          ecctl.Get().API,
+		DeploymentID: args[0],
+		RefID:        refID,
+		Contents:     &contents,
+	})
+	if err != nil {
+		return err
+	}
 
-		return ecctl.Get().Formatter.Format("deployment/eskeystore_show", res)
-	},
+	return ecctl.Get().Formatter.Format("deployment/eskeystore_show", res)
 }
 
 func init() {
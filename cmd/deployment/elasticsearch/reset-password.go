@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdelasticsearch
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/formatter"
+)
+
+const resetPasswordLong = `Resets the password of the Elasticsearch "elastic" superuser and prints the new
+credentials. Since the credentials can't be retrieved again, they're only printed
+once, and the request is never written to the --verbose output.
+
+The credentials can be written to a file with 0600 permissions with --output-file
+instead, and the .env format can be used for either of them with --env.`
+
+const resetPasswordExample = `## Reset the password and print the new credentials.
+$ ecctl deployment elasticsearch reset-password 5c17ad7c8df73206baa54b6e2829d9bc
+Username: elastic
+Password: 9s8AQ3ml1ef5LXRcSk0lZLv3
+
+## Reset the password and write the credentials to a .env file.
+$ ecctl deployment elasticsearch reset-password 5c17ad7c8df73206baa54b6e2829d9bc --env --output-file .env
+Credentials written to .env`
+
+var resetPasswordCmd = &cobra.Command{
+	Use:     "reset-password <deployment id> [--ref-id <ref-id>]",
+	Short:   `Resets the password of the Elasticsearch "elastic" superuser`,
+	Long:    resetPasswordLong,
+	Example: resetPasswordExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refID, _ := cmd.Flags().GetString("ref-id")
+		outputFile, _ := cmd.Flags().GetString("output-file")
+		env, _ := cmd.Flags().GetBool("env")
+
+		// Uses an API instance with the verbose output disabled so the
+		// credentials aren't written to it.
+		api, err := ecctl.Get().NewNonVerboseAPI()
+		if err != nil {
+			return err
+		}
+
+		res, err := depresourceapi.ResetElasticsearchPassword(depresourceapi.ResetElasticsearchPasswordParams{
+			API:   api,
+			ID:    args[0],
+			RefID: refID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if outputFile == "" {
+			if env {
+				return writeEnvCredentials(ecctl.Get().Config.OutputDevice, res)
+			}
+			return ecctl.Get().Formatter.Format("deployment/esresetpassword", res)
+		}
+
+		if err := writeCredentialsFile(outputFile, res, env); err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Credentials written to %s\n", outputFile)
+		return err
+	},
+}
+
+func init() {
+	Command.AddCommand(resetPasswordCmd)
+	initResetPasswordFlags()
+}
+
+func initResetPasswordFlags() {
+	resetPasswordCmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+	resetPasswordCmd.Flags().String("output-file", "", "Optional file to write the credentials to, created with 0600 permissions")
+	resetPasswordCmd.Flags().Bool("env", false, "Optionally writes the credentials in the .env format")
+}
+
+// writeEnvCredentials writes the credentials in the .env format.
+func writeEnvCredentials(w io.Writer, res *models.ElasticsearchElasticUserPasswordResetResponse) error {
+	_, err := fmt.Fprintf(w, "ELASTICSEARCH_USERNAME=%s\nELASTICSEARCH_PASSWORD=%s\n",
+		derefString(res.Username), derefString(res.Password),
+	)
+	return err
+}
+
+// writeCredentialsFile writes the credentials to the specified file in the
+// configured output format or in the .env format, making sure that only its
+// owner can read it.
+func writeCredentialsFile(path string, res *models.ElasticsearchElasticUserPasswordResetResponse, env bool) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// The permissions aren't changed by OpenFile when the file already exists.
+	if err := f.Chmod(0600); err != nil {
+		return err
+	}
+
+	if env {
+		return writeEnvCredentials(f, res)
+	}
+
+	return formatter.New(f, ecctl.Get().Config.Output).Format("deployment/esresetpassword", res)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdelasticsearch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_resetPasswordCmd(t *testing.T) {
+	const id = "320b7b540dfc967a7a649c18e2fce4ed"
+	var outputFile = filepath.Join(t.TempDir(), "credentials.env")
+	newResponse := func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Method: "POST",
+				Path:   "/api/v1/deployments/" + id + "/elasticsearch/main-elasticsearch/_reset-password",
+				Host:   api.DefaultMockHost,
+			},
+			mock.NewStructBody(models.ElasticsearchElasticUserPasswordResetResponse{
+				Username: ec.String("elastic"),
+				Password: ec.String("s3cr3t"),
+			}),
+		)
+	}
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "prints the credentials without writing them to the verbose output",
+			args: testutils.Args{
+				Cmd:  resetPasswordCmd,
+				Args: []string{"reset-password", id, "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Verbose:      true,
+					Responses:    []mock.Response{newResponse()},
+				},
+			},
+			want: testutils.Assertion{Stdout: "Username: elastic\nPassword: s3cr3t\n"},
+		},
+		{
+			name: "prints the credentials in the .env format",
+			args: testutils.Args{
+				Cmd:  resetPasswordCmd,
+				Args: []string{"reset-password", id, "--ref-id", "main-elasticsearch", "--env"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newResponse()}},
+			},
+			want: testutils.Assertion{Stdout: "ELASTICSEARCH_USERNAME=elastic\nELASTICSEARCH_PASSWORD=s3cr3t\n"},
+		},
+		{
+			name: "writes the credentials to a file",
+			args: testutils.Args{
+				Cmd:  resetPasswordCmd,
+				Args: []string{"reset-password", id, "--ref-id", "main-elasticsearch", "--env", "--output-file", outputFile},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newResponse()}},
+			},
+			want: testutils.Assertion{Stderr: "Credentials written to " + outputFile + "\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initResetPasswordFlags()
+		})
+	}
+
+	info, err := os.Stat(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, "ELASTICSEARCH_USERNAME=elastic\nELASTICSEARCH_PASSWORD=s3cr3t\n", string(b))
+}
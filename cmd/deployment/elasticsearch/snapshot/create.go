@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/esproxy"
+)
+
+const createExample = `## Take a snapshot of all the indices and wait for it to complete.
+$ ecctl deployment elasticsearch snapshot create 5c17ad7c8df73206baa54b6e2829d9bc before-upgrade --wait
+
+## Take a snapshot of the logs indices without the cluster state.
+$ ecctl deployment elasticsearch snapshot create 5c17ad7c8df73206baa54b6e2829d9bc logs-snapshot --indices "logs-*" --include-global-state=false`
+
+// createSnapshotRequest is the body of the Elasticsearch create snapshot API.
+type createSnapshotRequest struct {
+	Indices            []string `json:"indices,omitempty"`
+	IncludeGlobalState bool     `json:"include_global_state"`
+}
+
+var createCmd = &cobra.Command{
+	Use:     "create <deployment id> <snapshot name> [--indices <pattern>,...] [--wait]",
+	Short:   "Takes a snapshot of an Elasticsearch resource",
+	Example: createExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		indices, _ := cmd.Flags().GetStringSlice("indices")
+		globalState, _ := cmd.Flags().GetBool("include-global-state")
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		body, err := json.Marshal(createSnapshotRequest{
+			Indices:            indices,
+			IncludeGlobalState: globalState,
+		})
+		if err != nil {
+			return err
+		}
+
+		var path = snapshotPath(cmd, args[1])
+		if wait {
+			path += "?wait_for_completion=true"
+		}
+
+		var res struct {
+			Snapshot *snapshotInfo `json:"snapshot"`
+		}
+		if err := esproxy.DoJSON(newProxyParams(cmd, args[0], http.MethodPut, path, body), &res); err != nil {
+			return err
+		}
+
+		if res.Snapshot != nil {
+			return ecctl.Get().Formatter.Format("deployment/snapshotshow", res.Snapshot)
+		}
+
+		_, err = fmt.Fprintf(ecctl.Get().Config.OutputDevice, "Snapshot %s started\n", args[1])
+		return err
+	},
+}
+
+func init() {
+	Command.AddCommand(createCmd)
+	initCreateFlags()
+}
+
+func initCreateFlags() {
+	addSnapshotFlags(createCmd)
+	createCmd.Flags().StringSlice("indices", nil, "Optional comma-separated list of index patterns to include in the snapshot, all indices if not specified")
+	createCmd.Flags().Bool("include-global-state", true, "Includes the cluster state in the snapshot")
+	createCmd.Flags().Bool("wait", false, "Waits for the snapshot to complete and shows its details")
+}
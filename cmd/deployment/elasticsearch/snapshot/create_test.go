@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_createCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "starts a snapshot of all the indices",
+			args: testutils.Args{
+				Cmd:  createCmd,
+				Args: []string{"create", testDeploymentID, "before-upgrade", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newProxyResponse("PUT", "_snapshot/found-snapshots/before-upgrade",
+							`{"include_global_state":true}`, 200, `{"accepted":true}`,
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{Stdout: "Snapshot before-upgrade started\n"},
+		},
+		{
+			name: "takes a snapshot of the selected indices and waits for it",
+			args: testutils.Args{
+				Cmd: createCmd,
+				Args: []string{"create", testDeploymentID, "logs", "--ref-id", "main-elasticsearch",
+					"--indices", "logs-*,metrics-*", "--include-global-state=false", "--wait",
+				},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						newProxyResponse("PUT", "_snapshot/found-snapshots/logs?wait_for_completion=true",
+							`{"indices":["logs-*","metrics-*"],"include_global_state":false}`, 200,
+							`{"snapshot":{"snapshot":"logs","uuid":"dKb54xw67gvdRctLCxSket","indices":["logs-1"],"state":"SUCCESS","duration_in_millis":1200}}`,
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "SNAPSHOT     logs\n" +
+					"UUID         dKb54xw67gvdRctLCxSket\n" +
+					"STATE        SUCCESS\n" +
+					"VERSION      -\n" +
+					"START TIME   -\n" +
+					"END TIME     -\n" +
+					"DURATION     1.2s\n" +
+					"INDICES      1\n" +
+					"             logs-1\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initCreateFlags()
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/esproxy"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:     "delete <deployment id> <snapshot name> [--ref-id <ref-id>] [--repository <name>]",
+	Short:   "Deletes an Elasticsearch resource snapshot",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		msg := fmt.Sprintf("This action will delete the snapshot %s. Do you want to continue? [y/n]: ", args[1])
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		return esproxy.DoJSON(newProxyParams(cmd, args[0], http.MethodDelete, snapshotPath(cmd, args[1]), nil), nil)
+	},
+}
+
+func init() {
+	Command.AddCommand(deleteCmd)
+	initDeleteFlags()
+}
+
+func initDeleteFlags() {
+	addSnapshotFlags(deleteCmd)
+}
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"net/http"
+
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/esproxy"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list <deployment id> [--ref-id <ref-id>] [--repository <name>]",
+	Short:   "Lists the snapshots of an Elasticsearch resource",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var res snapshotList
+		if err := esproxy.DoJSON(newProxyParams(cmd, args[0], http.MethodGet, snapshotPath(cmd, "_all"), nil), &res); err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/snapshotlist", res)
+	},
+}
+
+func init() {
+	Command.AddCommand(listCmd)
+	initListFlags()
+}
+
+func initListFlags() {
+	addSnapshotFlags(listCmd)
+}
@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+const testDeploymentID = "320b7b540dfc967a7a649c18e2fce4ed"
+
+// newProxyResponse returns a response to a request to the Elasticsearch
+// proxy of the main-elasticsearch resource, where the path can contain a
+// query string.
+func newProxyResponse(method, path string, body string, status int, res string) mock.Response {
+	var query url.Values
+	if i := strings.Index(path, "?"); i >= 0 {
+		query, _ = url.ParseQuery(path[i+1:])
+		path = path[:i]
+	}
+
+	var headers = http.Header{
+		"Authorization":        {"ApiKey dummy"},
+		"User-Agent":           api.DefaultReadMockHeaders["User-Agent"],
+		"X-Management-Request": {"true"},
+	}
+	var reqBody = mock.NewStringBody(body)
+	if body == "" {
+		reqBody = nil
+	} else {
+		headers.Set("Content-Type", "application/json")
+	}
+
+	return mock.Response{
+		Response: http.Response{
+			StatusCode: status,
+			Body:       mock.NewStringBody(res),
+		},
+		Assert: &mock.RequestAssertion{
+			Header: headers,
+			Method: method,
+			Path:   "/api/v1/deployments/" + testDeploymentID + "/elasticsearch/main-elasticsearch/proxy/" + path,
+			Host:   api.DefaultMockHost,
+			Query:  query,
+			Body:   reqBody,
+		},
+	}
+}
+
+const testSnapshots = `{"snapshots":[{
+  "snapshot": "cloud-snapshot-2024.05.01",
+  "uuid": "dKb54xw67gvdRctLCxSket",
+  "repository": "found-snapshots",
+  "version": "8.13.2",
+  "indices": ["logs-1", "logs-2"],
+  "include_global_state": true,
+  "state": "SUCCESS",
+  "start_time": "2024-05-01T10:00:00.000Z",
+  "end_time": "2024-05-01T10:00:05.500Z",
+  "duration_in_millis": 5500,
+  "failures": [],
+  "shards": {"total": 2, "failed": 0, "successful": 2}
+}, {
+  "snapshot": "before-upgrade",
+  "uuid": "Yx9Fh3oHRsu2Y1xVYoZq8A",
+  "repository": "found-snapshots",
+  "version": "8.13.2",
+  "indices": ["logs-1"],
+  "state": "PARTIAL",
+  "start_time": "2024-05-02T10:00:00.000Z",
+  "end_time": "2024-05-02T10:01:00.000Z",
+  "duration_in_millis": 60000,
+  "failures": [{"index": "logs-1", "shard_id": 0, "reason": "IndexShardSnapshotFailedException", "status": "INTERNAL_SERVER_ERROR"}],
+  "shards": {"total": 1, "failed": 1, "successful": 0}
+}]}`
+
+func Test_listCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "lists the snapshots",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", testDeploymentID, "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						newProxyResponse("GET", "_snapshot/found-snapshots/_all", "", 200, testSnapshots),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "SNAPSHOT                    STATE     START TIME                 DURATION   INDICES   FAILED SHARDS\n" +
+					"cloud-snapshot-2024.05.01   SUCCESS   2024-05-01T10:00:00.000Z   5.5s       2         0\n" +
+					"before-upgrade              PARTIAL   2024-05-02T10:00:00.000Z   1m0s       1         1\n",
+			},
+		},
+		{
+			name: "lists the snapshots of another repository",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", testDeploymentID, "--ref-id", "main-elasticsearch", "--repository", "my-repo"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newProxyResponse("GET", "_snapshot/my-repo/_all", "", 200, `{"snapshots":[]}`),
+					},
+				},
+			},
+			want: testutils.Assertion{Stdout: "{\n  \"snapshots\": []\n}\n"},
+		},
+		{
+			name: "fails when elasticsearch returns an error",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", testDeploymentID, "--ref-id", "main-elasticsearch", "--repository", "missing"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newProxyResponse("GET", "_snapshot/missing/_all", "", 404,
+							`{"error":{"type":"repository_missing_exception"},"status":404}`,
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Err: `elasticsearch returned status 404: {"error":{"type":"repository_missing_exception"},"status":404}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initListFlags()
+		})
+	}
+}
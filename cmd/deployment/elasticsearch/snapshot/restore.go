@@ -0,0 +1,234 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/esproxy"
+)
+
+// latestSuccessfulSnapshot is the snapshot name which Elastic Cloud resolves
+// to the latest successful snapshot when restoring from another deployment.
+const latestSuccessfulSnapshot = "__latest_success__"
+
+const restoreLong = `Restores a snapshot into an Elasticsearch resource. The indices to restore can
+be selected with --indices, and renamed while restoring them with --rename-pattern
+and --rename-replacement, which is required when the indices already exist.
+
+By default, the snapshot is taken from the deployment's own repository. When
+--source-deployment is specified, the snapshot is taken from the repository of
+another deployment in the same region by applying a plan to the Elasticsearch
+resource, where "` + latestSuccessfulSnapshot + `" can be used as the snapshot name to
+restore the latest successful snapshot.`
+
+const restoreExample = `## Restore the logs indices of a snapshot, renaming them to restored-logs-*.
+$ ecctl deployment elasticsearch snapshot restore 5c17ad7c8df73206baa54b6e2829d9bc before-upgrade --indices "logs-*" --rename-pattern "logs-(.+)" --rename-replacement "restored-logs-\$1"
+
+## Restore the latest successful snapshot of another deployment and track the change.
+$ ecctl deployment elasticsearch snapshot restore 5c17ad7c8df73206baa54b6e2829d9bc __latest_success__ --source-deployment 9ef35c1ff6f23a1c8a8f5b6e3d53b6ff --track`
+
+// restoreSnapshotRequest is the body of the Elasticsearch restore snapshot API.
+type restoreSnapshotRequest struct {
+	Indices            []string `json:"indices,omitempty"`
+	RenamePattern      string   `json:"rename_pattern,omitempty"`
+	RenameReplacement  string   `json:"rename_replacement,omitempty"`
+	IncludeGlobalState bool     `json:"include_global_state"`
+}
+
+var restoreCmd = &cobra.Command{
+	Use:     "restore <deployment id> <snapshot name> [--source-deployment <id>] [--indices <pattern>,...]",
+	Short:   "Restores a snapshot into an Elasticsearch resource",
+	Long:    restoreLong,
+	Example: restoreExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := sdkcmdutil.NoneOrBothFlags(cmd, "rename-pattern", "rename-replacement"); err != nil {
+			return err
+		}
+
+		indices, _ := cmd.Flags().GetStringSlice("indices")
+		renamePattern, _ := cmd.Flags().GetString("rename-pattern")
+		renameReplacement, _ := cmd.Flags().GetString("rename-replacement")
+		globalState, _ := cmd.Flags().GetBool("include-global-state")
+		source, _ := cmd.Flags().GetString("source-deployment")
+		var req = restoreSnapshotRequest{
+			Indices:            indices,
+			RenamePattern:      renamePattern,
+			RenameReplacement:  renameReplacement,
+			IncludeGlobalState: globalState,
+		}
+
+		if source != "" {
+			return restoreFromDeployment(cmd, args[0], args[1], source, req)
+		}
+
+		if cmd.Flag("track").Changed {
+			return errors.New("--track can only be used with --source-deployment")
+		}
+
+		body, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+
+		wait, _ := cmd.Flags().GetBool("wait")
+		var path = snapshotPath(cmd, args[1], "_restore")
+		if wait {
+			path += "?wait_for_completion=true"
+		}
+
+		var res struct {
+			Snapshot *struct {
+				Indices []string            `json:"indices"`
+				Shards  *snapshotShardStats `json:"shards"`
+			} `json:"snapshot"`
+		}
+		if err := esproxy.DoJSON(newProxyParams(cmd, args[0], http.MethodPost, path, body), &res); err != nil {
+			return err
+		}
+
+		var msg = fmt.Sprintf("Restore of snapshot %s started\n", args[1])
+		if s := res.Snapshot; s != nil {
+			msg = fmt.Sprintf("Restored %d indices from snapshot %s\n", len(s.Indices), args[1])
+			if s.Shards != nil && s.Shards.Failed > 0 {
+				msg = fmt.Sprintf("Restored %d indices from snapshot %s, %d of %d shards failed\n",
+					len(s.Indices), args[1], s.Shards.Failed, s.Shards.Total,
+				)
+			}
+		}
+
+		_, err = fmt.Fprint(ecctl.Get().Config.OutputDevice, msg)
+		return err
+	},
+}
+
+func init() {
+	Command.AddCommand(restoreCmd)
+	initRestoreFlags()
+}
+
+func initRestoreFlags() {
+	addSnapshotFlags(restoreCmd)
+	restoreCmd.Flags().StringSlice("indices", nil, "Optional comma-separated list of index patterns to restore, all indices if not specified")
+	restoreCmd.Flags().String("rename-pattern", "", "Optional regular expression matching the names of the indices to rename")
+	restoreCmd.Flags().String("rename-replacement", "", "Optional replacement for the names matched by --rename-pattern, which can reference its groups")
+	restoreCmd.Flags().Bool("include-global-state", false, "Restores the cluster state of the snapshot")
+	restoreCmd.Flags().String("source-deployment", "", "Optional ID of the deployment whose snapshot is restored, the same deployment if not specified")
+	restoreCmd.Flags().Bool("wait", false, "Waits for the restore to complete, only used without --source-deployment")
+	restoreCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage+", only used with --source-deployment")
+}
+
+// restoreFromDeployment restores a snapshot of the source deployment by
+// applying a plan with the restore settings to the Elasticsearch resource.
+func restoreFromDeployment(cmd *cobra.Command, id, snapshot, source string, req restoreSnapshotRequest) error {
+	if cmd.Flag("wait").Changed {
+		return errors.New("--wait cannot be used with --source-deployment, use --track instead")
+	}
+
+	sourceRes, err := deploymentapi.Get(deploymentapi.GetParams{
+		API:          ecctl.Get().API,
+		DeploymentID: source,
+	})
+	if err != nil {
+		return err
+	}
+
+	sourceES, err := getElasticsearchResource(sourceRes, "")
+	if err != nil {
+		return fmt.Errorf("source deployment: %w", err)
+	}
+
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API:          ecctl.Get().API,
+		DeploymentID: id,
+		QueryParams: deputil.QueryParams{
+			ShowPlans:      true,
+			ShowSettings:   true,
+			ClearTransient: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	refID, _ := cmd.Flags().GetString("ref-id")
+	update, payload, err := newElasticsearchUpdateRequest(res, refID)
+	if err != nil {
+		return err
+	}
+
+	var restore = models.RestoreSnapshotConfiguration{
+		SourceClusterID: *sourceES.ID,
+		SnapshotName:    ec.String(snapshot),
+	}
+	if cmd.Flag("repository").Changed {
+		restore.RepositoryName, _ = cmd.Flags().GetString("repository")
+	}
+	if len(req.Indices) > 0 || req.RenamePattern != "" || req.IncludeGlobalState {
+		var settings = map[string]interface{}{
+			"include_global_state": req.IncludeGlobalState,
+		}
+		if req.RenamePattern != "" {
+			settings["rename_pattern"] = req.RenamePattern
+			settings["rename_replacement"] = req.RenameReplacement
+		}
+		var indices = req.Indices
+		if len(indices) == 0 {
+			indices = []string{"*"}
+		}
+		restore.RestorePayload = &models.RestoreSnapshotAPIConfiguration{
+			Indices:     indices,
+			RawSettings: settings,
+		}
+	}
+
+	if payload.Plan.Transient == nil {
+		payload.Plan.Transient = new(models.TransientElasticsearchPlanConfiguration)
+	}
+	payload.Plan.Transient.RestoreSnapshot = &restore
+
+	updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		DeploymentID: id,
+		API:          ecctl.Get().API,
+		Request:      update,
+	})
+	if err != nil {
+		return err
+	}
+
+	track, _ := cmd.Flags().GetBool("track")
+	return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+		App:          ecctl.Get(),
+		DeploymentID: id,
+		Track:        track,
+		Response:     updateRes,
+	}))
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func newSnapshotTestDeployment(refIDs ...string) *models.DeploymentGetResponse {
+	var res = models.DeploymentGetResponse{
+		ID:        ec.String(testDeploymentID),
+		Name:      ec.String("my-deployment"),
+		Resources: &models.DeploymentResources{},
+	}
+	for _, refID := range refIDs {
+		res.Resources.Elasticsearch = append(res.Resources.Elasticsearch, &models.ElasticsearchResourceInfo{
+			ID:     ec.String("3531aaf988594efa87c1aabb7caed337"),
+			RefID:  ec.String(refID),
+			Region: ec.String("us-east-1"),
+			Info: &models.ElasticsearchClusterInfo{
+				ClusterName: ec.String("my-deployment"),
+				PlanInfo: &models.ElasticsearchClusterPlansInfo{
+					Current: &models.ElasticsearchClusterPlanInfo{
+						Plan: &models.ElasticsearchClusterPlan{
+							Elasticsearch: &models.ElasticsearchConfiguration{Version: "8.13.2"},
+						},
+					},
+				},
+				Settings: &models.ElasticsearchClusterSettings{
+					Snapshot: &models.ClusterSnapshotSettings{
+						Interval:  "30m",
+						Retention: &models.ClusterSnapshotRetention{Snapshots: 100},
+					},
+				},
+			},
+		})
+	}
+	return &res
+}
+
+func Test_newElasticsearchUpdateRequest(t *testing.T) {
+	req, payload, err := newElasticsearchUpdateRequest(newSnapshotTestDeployment("main-elasticsearch"), "")
+	require.NoError(t, err)
+	assert.Equal(t, "main-elasticsearch", *payload.RefID)
+	assert.Equal(t, []*models.ElasticsearchPayload{payload}, req.Resources.Elasticsearch)
+	assert.Empty(t, req.Resources.Kibana)
+	assert.False(t, *req.PruneOrphans)
+
+	_, _, err = newElasticsearchUpdateRequest(newSnapshotTestDeployment("main-elasticsearch"), "other")
+	assert.EqualError(t, err, "deployment has no elasticsearch resource with ref id other")
+
+	_, _, err = newElasticsearchUpdateRequest(newSnapshotTestDeployment("es-1", "es-2"), "")
+	assert.EqualError(t, err, "deployment has more than one elasticsearch resource, please specify --ref-id")
+
+	_, _, err = newElasticsearchUpdateRequest(newSnapshotTestDeployment(), "")
+	assert.EqualError(t, err, "deployment has no elasticsearch resources")
+}
+
+func Test_restoreCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "restores the selected indices renaming them",
+			args: testutils.Args{
+				Cmd: restoreCmd,
+				Args: []string{"restore", testDeploymentID, "before-upgrade", "--ref-id", "main-elasticsearch",
+					"--indices", "logs-*", "--rename-pattern", "logs-(.+)", "--rename-replacement", "restored-logs-$1",
+				},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newProxyResponse("POST", "_snapshot/found-snapshots/before-upgrade/_restore",
+							`{"indices":["logs-*"],"rename_pattern":"logs-(.+)","rename_replacement":"restored-logs-$1","include_global_state":false}`,
+							200, `{"accepted":true}`,
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{Stdout: "Restore of snapshot before-upgrade started\n"},
+		},
+		{
+			name: "restores the snapshot and waits for it",
+			args: testutils.Args{
+				Cmd:  restoreCmd,
+				Args: []string{"restore", testDeploymentID, "before-upgrade", "--ref-id", "main-elasticsearch", "--wait"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newProxyResponse("POST", "_snapshot/found-snapshots/before-upgrade/_restore?wait_for_completion=true",
+							`{"include_global_state":false}`, 200,
+							`{"snapshot":{"snapshot":"before-upgrade","indices":["logs-1","logs-2"],"shards":{"total":2,"failed":1,"successful":1}}}`,
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{Stdout: "Restored 2 indices from snapshot before-upgrade, 1 of 2 shards failed\n"},
+		},
+		{
+			name: "fails when the rename replacement isn't specified",
+			args: testutils.Args{
+				Cmd:  restoreCmd,
+				Args: []string{"restore", testDeploymentID, "before-upgrade", "--rename-pattern", "logs-(.+)"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{Err: `flags "--rename-pattern" and "--rename-replacement", should be both used or none`},
+		},
+		{
+			name: "fails when --wait is used with --source-deployment",
+			args: testutils.Args{
+				Cmd:  restoreCmd,
+				Args: []string{"restore", testDeploymentID, latestSuccessfulSnapshot, "--source-deployment", "9ef35c1ff6f23a1c8a8f5b6e3d53b6ff", "--wait"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{Err: "--wait cannot be used with --source-deployment, use --track instead"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initRestoreFlags()
+		})
+	}
+}
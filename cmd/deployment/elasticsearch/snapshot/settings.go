@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const settingsSetExample = `## Take a snapshot every 2 hours and keep the snapshots for 7 days.
+$ ecctl deployment elasticsearch snapshot settings set 5c17ad7c8df73206baa54b6e2829d9bc --interval 2h --retention-max-age 7d
+
+## Keep the 50 most recent snapshots and track the change.
+$ ecctl deployment elasticsearch snapshot settings set 5c17ad7c8df73206baa54b6e2829d9bc --retention-snapshots 50 --track`
+
+var settingsCmd = &cobra.Command{
+	Use:     "settings",
+	Short:   "Manages the cloud-managed snapshot settings of Elasticsearch resources",
+	PreRunE: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var settingsShowCmd = &cobra.Command{
+	Use:     "show <deployment id> [--ref-id <ref-id>]",
+	Short:   "Shows the snapshot interval and retention of an Elasticsearch resource",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refID, _ := cmd.Flags().GetString("ref-id")
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowSettings: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		resource, err := getElasticsearchResource(res, refID)
+		if err != nil {
+			return err
+		}
+
+		var settings = new(models.ClusterSnapshotSettings)
+		if s := resource.Info.Settings; s != nil && s.Snapshot != nil {
+			settings = s.Snapshot
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/snapshotsettings", settings)
+	},
+}
+
+var settingsSetCmd = &cobra.Command{
+	Use:     "set <deployment id> [--interval <interval>] [--retention-max-age <age>] [--retention-snapshots <count>]",
+	Short:   "Sets the snapshot interval and retention of an Elasticsearch resource",
+	Example: settingsSetExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refID, _ := cmd.Flags().GetString("ref-id")
+		interval, _ := cmd.Flags().GetString("interval")
+		maxAge, _ := cmd.Flags().GetString("retention-max-age")
+		snapshots, _ := cmd.Flags().GetInt32("retention-snapshots")
+
+		if !cmd.Flag("interval").Changed && !cmd.Flag("retention-max-age").Changed &&
+			!cmd.Flag("retention-snapshots").Changed {
+			return errors.New("at least one of --interval, --retention-max-age or --retention-snapshots must be specified")
+		}
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:      true,
+				ShowSettings:   true,
+				ClearTransient: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		req, payload, err := newElasticsearchUpdateRequest(res, refID)
+		if err != nil {
+			return err
+		}
+
+		if payload.Settings == nil {
+			payload.Settings = new(models.ElasticsearchClusterSettings)
+		}
+		if payload.Settings.Snapshot == nil {
+			payload.Settings.Snapshot = new(models.ClusterSnapshotSettings)
+		}
+
+		var settings = payload.Settings.Snapshot
+		if cmd.Flag("interval").Changed {
+			settings.Interval = interval
+		}
+		if cmd.Flag("retention-max-age").Changed || cmd.Flag("retention-snapshots").Changed {
+			if settings.Retention == nil {
+				settings.Retention = new(models.ClusterSnapshotRetention)
+			}
+			if cmd.Flag("retention-max-age").Changed {
+				settings.Retention.MaxAge = maxAge
+			}
+			if cmd.Flag("retention-snapshots").Changed {
+				settings.Retention.Snapshots = snapshots
+			}
+		}
+
+		updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+			DeploymentID: args[0],
+			API:          ecctl.Get().API,
+			Request:      req,
+		})
+		if err != nil {
+			return err
+		}
+
+		track, _ := cmd.Flags().GetBool("track")
+		return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+			App:          ecctl.Get(),
+			DeploymentID: args[0],
+			Track:        track,
+			Response:     updateRes,
+		}))
+	},
+}
+
+func init() {
+	Command.AddCommand(settingsCmd)
+	settingsCmd.AddCommand(settingsShowCmd, settingsSetCmd)
+	initSettingsShowFlags()
+	initSettingsSetFlags()
+}
+
+func initSettingsShowFlags() {
+	settingsShowCmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+}
+
+func initSettingsSetFlags() {
+	settingsSetCmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+	settingsSetCmd.Flags().String("interval", "", "Optional interval between the snapshots, such as 30m or 2h")
+	settingsSetCmd.Flags().String("retention-max-age", "", "Optional maximum age of the snapshots to keep, such as 7d")
+	settingsSetCmd.Flags().Int32("retention-snapshots", 0, "Optional maximum number of snapshots to keep")
+	settingsSetCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+}
@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_settingsShowCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "shows the snapshot settings",
+			args: testutils.Args{
+				Cmd:  settingsShowCmd,
+				Args: []string{"settings", "show", testDeploymentID},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultReadMockHeaders,
+								Method: "GET",
+								Path:   "/api/v1/deployments/" + testDeploymentID,
+								Host:   api.DefaultMockHost,
+								Query: url.Values{
+									"convert_legacy_plans": {"false"},
+									"show_metadata":        {"false"},
+									"show_plan_defaults":   {"false"},
+									"show_plan_history":    {"false"},
+									"show_plan_logs":       {"false"},
+									"show_plans":           {"false"},
+									"show_settings":        {"true"},
+									"show_system_alerts":   {"5"},
+								},
+							},
+							mock.NewStructBody(newSnapshotTestDeployment("main-elasticsearch")),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "INTERVAL   RETENTION MAX AGE   RETENTION SNAPSHOTS\n" +
+					"30m        -                   100\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initSettingsShowFlags()
+		})
+	}
+}
+
+func Test_settingsSetCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "fails when no settings are specified",
+			args: testutils.Args{
+				Cmd:  settingsSetCmd,
+				Args: []string{"settings", "set", testDeploymentID},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{
+				Err: "at least one of --interval, --retention-max-age or --retention-snapshots must be specified",
+			},
+		},
+		{
+			name: "updates the snapshot settings",
+			args: testutils.Args{
+				Cmd:  settingsSetCmd,
+				Args: []string{"settings", "set", testDeploymentID, "--interval", "2h", "--retention-max-age", "7d"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultReadMockHeaders,
+								Method: "GET",
+								Path:   "/api/v1/deployments/" + testDeploymentID,
+								Host:   api.DefaultMockHost,
+								Query: url.Values{
+									"clear_transient":      {"true"},
+									"convert_legacy_plans": {"false"},
+									"show_metadata":        {"false"},
+									"show_plan_defaults":   {"false"},
+									"show_plan_history":    {"false"},
+									"show_plan_logs":       {"false"},
+									"show_plans":           {"true"},
+									"show_settings":        {"true"},
+									"show_system_alerts":   {"5"},
+								},
+							},
+							mock.NewStructBody(newSnapshotTestDeployment("main-elasticsearch")),
+						),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/" + testDeploymentID,
+								Host:   api.DefaultMockHost,
+								Query:  url.Values{"hide_pruned_orphans": {"false"}, "skip_snapshot": {"false"}, "validate_only": {"false"}},
+								Body: mock.NewStringBody(`{"name":"my-deployment","prune_orphans":false,"resources":{"apm":null,"appsearch":null,` +
+									`"elasticsearch":[{"display_name":"my-deployment","plan":{"cluster_topology":null,"elasticsearch":{"version":"8.13.2"}},` +
+									`"ref_id":"main-elasticsearch","region":"us-east-1","settings":{"snapshot":{"interval":"2h",` +
+									`"retention":{"max_age":"7d","snapshots":100},"suspended":null}}}],` +
+									`"enterprise_search":null,"integrations_server":null,"kibana":null}}` + "\n"),
+							},
+							mock.NewStringBody(`{"id":"`+testDeploymentID+`"}`),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "{\n  \"id\": \"" + testDeploymentID + "\",\n  \"name\": null,\n  \"resources\": null\n}\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initSettingsSetFlags()
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_showCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "shows the snapshot details",
+			args: testutils.Args{
+				Cmd:  showCmd,
+				Args: []string{"show", testDeploymentID, "before-upgrade", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						newProxyResponse("GET", "_snapshot/found-snapshots/before-upgrade", "", 200,
+							`{"snapshots":[{"snapshot":"before-upgrade","uuid":"Yx9Fh3oHRsu2Y1xVYoZq8A","version":"8.13.2",`+
+								`"indices":["logs-1","logs-2"],"state":"PARTIAL","start_time":"2024-05-02T10:00:00.000Z",`+
+								`"end_time":"2024-05-02T10:01:00.000Z","duration_in_millis":60000,"failures":[{"index":"logs-1",`+
+								`"shard_id":0,"reason":"IndexShardSnapshotFailedException"}],"shards":{"total":2,"failed":1,"successful":1}}]}`,
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "SNAPSHOT     before-upgrade\n" +
+					"UUID         Yx9Fh3oHRsu2Y1xVYoZq8A\n" +
+					"STATE        PARTIAL\n" +
+					"VERSION      8.13.2\n" +
+					"START TIME   2024-05-02T10:00:00.000Z\n" +
+					"END TIME     2024-05-02T10:01:00.000Z\n" +
+					"DURATION     1m0s\n" +
+					"SHARDS       1/2 successful, 1 failed\n" +
+					"INDICES      2\n" +
+					"             logs-1\n" +
+					"             logs-2\n" +
+					"FAILURE      logs-1[0]: IndexShardSnapshotFailedException\n",
+			},
+		},
+		{
+			name: "fails when the snapshot isn't returned",
+			args: testutils.Args{
+				Cmd:  showCmd,
+				Args: []string{"show", testDeploymentID, "missing", "--ref-id", "main-elasticsearch"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newProxyResponse("GET", "_snapshot/found-snapshots/missing", "", 200, `{"snapshots":[]}`),
+					},
+				},
+			},
+			want: testutils.Assertion{Err: "snapshot missing not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initShowFlags()
+		})
+	}
+}
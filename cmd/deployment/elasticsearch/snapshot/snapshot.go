@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdessnapshot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/esproxy"
+)
+
+// defaultRepository is the snapshot repository which Elastic Cloud manages
+// for every deployment.
+const defaultRepository = "found-snapshots"
+
+// snapshotInfo is the Elasticsearch snapshot information.
+type snapshotInfo struct {
+	Snapshot           string              `json:"snapshot"`
+	UUID               string              `json:"uuid,omitempty"`
+	Repository         string              `json:"repository,omitempty"`
+	Version            string              `json:"version,omitempty"`
+	Indices            []string            `json:"indices"`
+	DataStreams        []string            `json:"data_streams,omitempty"`
+	IncludeGlobalState *bool               `json:"include_global_state,omitempty"`
+	State              string              `json:"state"`
+	Reason             string              `json:"reason,omitempty"`
+	StartTime          string              `json:"start_time,omitempty"`
+	EndTime            string              `json:"end_time,omitempty"`
+	DurationInMillis   int64               `json:"duration_in_millis"`
+	Failures           []snapshotFailure   `json:"failures,omitempty"`
+	Shards             *snapshotShardStats `json:"shards,omitempty"`
+}
+
+type snapshotFailure struct {
+	Index   string `json:"index"`
+	ShardID int    `json:"shard_id"`
+	Reason  string `json:"reason"`
+	Status  string `json:"status"`
+}
+
+type snapshotShardStats struct {
+	Total      int `json:"total"`
+	Failed     int `json:"failed"`
+	Successful int `json:"successful"`
+}
+
+// snapshotList is the response of the Elasticsearch get snapshot API.
+type snapshotList struct {
+	Snapshots []snapshotInfo `json:"snapshots"`
+}
+
+// Duration returns the snapshot's duration in a human readable format.
+func (s snapshotInfo) Duration() string {
+	return (time.Duration(s.DurationInMillis) * time.Millisecond).String()
+}
+
+// newProxyParams returns the parameters for a request to the Elasticsearch
+// resource specified by the command's --ref-id flag.
+func newProxyParams(cmd *cobra.Command, id, method, path string, body []byte) esproxy.Params {
+	refID, _ := cmd.Flags().GetString("ref-id")
+	return esproxy.Params{
+		API:          ecctl.Get().API,
+		Host:         ecctl.Get().Config.Host,
+		Client:       ecctl.Get().Config.Client,
+		DeploymentID: id,
+		RefID:        refID,
+		Method:       method,
+		Path:         path,
+		Body:         body,
+	}
+}
+
+// snapshotPath returns the snapshot API path of the repository specified by
+// the command's --repository flag, followed by the specified path segments.
+func snapshotPath(cmd *cobra.Command, segments ...string) string {
+	repository, _ := cmd.Flags().GetString("repository")
+	var path = "_snapshot/" + url.PathEscape(repository)
+	for _, s := range segments {
+		path += "/" + url.PathEscape(s)
+	}
+	return path
+}
+
+// addSnapshotFlags adds the flags which are common to the commands
+// interacting with the Elasticsearch snapshot API.
+func addSnapshotFlags(cmd *cobra.Command) {
+	cmd.Flags().String("ref-id", "", "Optional ref_id to use for the Elasticsearch resource, auto-discovered if not specified.")
+	cmd.Flags().String("repository", defaultRepository, "Snapshot repository to use")
+}
+
+// getSnapshot returns the specified snapshot.
+func getSnapshot(cmd *cobra.Command, id, name string) (*snapshotInfo, error) {
+	var res snapshotList
+	if err := esproxy.DoJSON(newProxyParams(cmd, id, http.MethodGet, snapshotPath(cmd, name), nil), &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Snapshots) != 1 {
+		return nil, fmt.Errorf("snapshot %s not found", name)
+	}
+
+	return &res.Snapshots[0], nil
+}
+
+// getElasticsearchResource returns the Elasticsearch resource with the
+// specified ref ID, or the deployment's only Elasticsearch resource when the
+// ref ID is empty.
+func getElasticsearchResource(res *models.DeploymentGetResponse, refID string) (*models.ElasticsearchResourceInfo, error) {
+	var matches []*models.ElasticsearchResourceInfo
+	for _, r := range res.Resources.Elasticsearch {
+		if refID == "" || *r.RefID == refID {
+			matches = append(matches, r)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		return nil, errors.New("deployment has more than one elasticsearch resource, please specify --ref-id")
+	case refID != "":
+		return nil, fmt.Errorf("deployment has no elasticsearch resource with ref id %s", refID)
+	default:
+		return nil, errors.New("deployment has no elasticsearch resources")
+	}
+}
+
+// newElasticsearchUpdateRequest returns a deployment update request which only
+// contains the specified Elasticsearch resource, along with the resource's
+// payload so it can be modified.
+func newElasticsearchUpdateRequest(res *models.DeploymentGetResponse, refID string) (*models.DeploymentUpdateRequest, *models.ElasticsearchPayload, error) {
+	resource, err := getElasticsearchResource(res, refID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var current = deploymentapi.NewUpdateRequest(res)
+	for _, r := range current.Resources.Elasticsearch {
+		if *r.RefID != *resource.RefID {
+			continue
+		}
+		return &models.DeploymentUpdateRequest{
+			Name:         current.Name,
+			PruneOrphans: ec.Bool(false),
+			Resources: &models.DeploymentUpdateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{r},
+			},
+		}, r, nil
+	}
+
+	return nil, nil, fmt.Errorf("elasticsearch resource %s has no current plan", *resource.RefID)
+}
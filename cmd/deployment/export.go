@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/tfexport"
+)
+
+const (
+	exportFormatTerraform = "terraform"
+	exportFormatJSON      = "json"
+)
+
+const exportExample = `## Export a deployment, its traffic filters and extensions as Terraform configuration
+## with import blocks, which adopt the existing resources on the next terraform apply.
+$ ecctl deployment export 5c17ad7c8df73206baa54b6e2829d9bc --format terraform > deployment.tf
+
+## Print the terraform import commands instead, for Terraform versions prior to 1.5.
+$ ecctl deployment export 5c17ad7c8df73206baa54b6e2829d9bc --format terraform --import command
+
+## Export the deployment as an update payload, same as "show --generate-update-payload".
+$ ecctl deployment export 5c17ad7c8df73206baa54b6e2829d9bc --format json > update.json`
+
+var exportCmd = &cobra.Command{
+	Use:     "export <deployment-id> --format <terraform|json>",
+	Short:   "Exports a deployment configuration, as Terraform configuration or as an update payload",
+	Example: exportExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		importMode, _ := cmd.Flags().GetString("import")
+
+		switch format {
+		case exportFormatTerraform:
+			return tfexport.Export(ecctl.Get().Config.OutputDevice, tfexport.Params{
+				API:          ecctl.Get().API,
+				DeploymentID: args[0],
+				Import:       importMode,
+			})
+		case exportFormatJSON:
+			res, err := deploymentapi.Get(deploymentapi.GetParams{
+				API:          ecctl.Get().API,
+				DeploymentID: args[0],
+				QueryParams: deputil.QueryParams{
+					ShowPlans:      true,
+					ShowSettings:   true,
+					ClearTransient: true,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			enc := json.NewEncoder(ecctl.Get().Config.OutputDevice)
+			enc.SetIndent("", "  ")
+			return enc.Encode(deploymentapi.NewUpdateRequest(res))
+		default:
+			return fmt.Errorf(`invalid export format "%s", must be one of [%s|%s]`,
+				format, exportFormatTerraform, exportFormatJSON,
+			)
+		}
+	},
+}
+
+func init() {
+	initExportFlags()
+}
+
+func initExportFlags() {
+	Command.AddCommand(exportCmd)
+	// The format flag shadows the global --format flag, which formats the
+	// output using a Go template.
+	exportCmd.Flags().String("format", exportFormatTerraform, fmt.Sprintf(
+		"Export format [%s|%s]", exportFormatTerraform, exportFormatJSON,
+	))
+	exportCmd.Flags().String("import", tfexport.ImportBlock, fmt.Sprintf(
+		"How the existing resources are imported into the Terraform state [%s]", strings.Join(tfexport.ImportModes, "|"),
+	))
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	_ "embed"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+//go:embed "testdata/export.json"
+var exportRawResp []byte
+
+//go:embed "testdata/want_export.tf"
+var wantExport string
+
+func Test_exportCmd(t *testing.T) {
+	const id = "5c17ad7c8df73206baa54b6e2829d9bc"
+	var getQuery = url.Values{
+		"convert_legacy_plans": {"false"},
+		"show_metadata":        {"true"},
+		"show_plan_defaults":   {"false"},
+		"show_plan_history":    {"false"},
+		"show_plan_logs":       {"false"},
+		"show_plans":           {"true"},
+		"show_settings":        {"true"},
+		"show_system_alerts":   {"5"},
+	}
+	newResponses := func() []mock.Response {
+		return []mock.Response{
+			mock.New200ResponseAssertion(
+				&mock.RequestAssertion{
+					Header: api.DefaultReadMockHeaders,
+					Method: "GET",
+					Path:   "/api/v1/deployments/" + id,
+					Host:   api.DefaultMockHost,
+					Query:  getQuery,
+				},
+				mock.NewByteBody(exportRawResp),
+			),
+			mock.New200ResponseAssertion(
+				&mock.RequestAssertion{
+					Header: api.DefaultReadMockHeaders,
+					Method: "GET",
+					Path:   "/api/v1/deployments/traffic-filter/rulesets/4e974d9476534d35b12fbdcfd0acee0a",
+					Host:   api.DefaultMockHost,
+					Query:  url.Values{"include_associations": {"false"}},
+				},
+				mock.NewStructBody(models.TrafficFilterRulesetInfo{
+					ID:               ec.String("4e974d9476534d35b12fbdcfd0acee0a"),
+					Name:             ec.String("office"),
+					Region:           ec.String("us-east-1"),
+					Type:             ec.String("ip"),
+					IncludeByDefault: ec.Bool(false),
+					Rules: []*models.TrafficFilterRule{
+						{Source: "192.168.0.0/24", Description: "office network"},
+						{Source: "10.0.0.1"},
+					},
+				}),
+			),
+			mock.New200ResponseAssertion(
+				&mock.RequestAssertion{
+					Header: api.DefaultReadMockHeaders,
+					Method: "GET",
+					Path:   "/api/v1/deployments/extensions/2649887448",
+					Host:   api.DefaultMockHost,
+					Query:  url.Values{"include_deployments": {"false"}},
+				},
+				mock.NewStructBody(models.Extension{
+					ID:            ec.String("2649887448"),
+					Name:          ec.String("synonyms"),
+					Description:   "Synonym files",
+					ExtensionType: ec.String("bundle"),
+					URL:           ec.String("repo://2649887448"),
+					Version:       ec.String("*"),
+				}),
+			),
+		}
+	}
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "exports the deployment as terraform configuration",
+			args: testutils.Args{
+				Cmd:  exportCmd,
+				Args: []string{"export", id},
+				Cfg:  testutils.MockCfg{Responses: newResponses()},
+			},
+			want: testutils.Assertion{Stdout: wantExport},
+		},
+		{
+			name: "exports the deployment with the terraform import commands",
+			args: testutils.Args{
+				Cmd:  exportCmd,
+				Args: []string{"export", id, "--import", "command"},
+				Cfg:  testutils.MockCfg{Responses: newResponses()},
+			},
+			want: testutils.Assertion{
+				Stdout: "# Import the existing resources into the Terraform state with:\n" +
+					"#   terraform import ec_deployment.my_deployment " + id + "\n" +
+					"#   terraform import ec_deployment_extension.synonyms 2649887448\n" +
+					"#   terraform import ec_deployment_traffic_filter.office 4e974d9476534d35b12fbdcfd0acee0a\n\n" +
+					wantExport[:strings.Index(wantExport, "\nimport {")],
+			},
+		},
+		{
+			name: "fails with an invalid format",
+			args: testutils.Args{
+				Cmd:  exportCmd,
+				Args: []string{"export", id, "--format", "hcl"},
+			},
+			want: testutils.Assertion{Err: `invalid export format "hcl", must be one of [terraform|json]`},
+		},
+		{
+			name: "fails with an invalid import mode",
+			args: testutils.Args{
+				Cmd:  exportCmd,
+				Args: []string{"export", id, "--import", "script"},
+			},
+			want: testutils.Assertion{
+				Err: "invalid terraform export params: 1 error occurred:\n\t* invalid import mode \"script\", must be one of [block|command|none]\n\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initExportFlags()
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentextension
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/extensionapi"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+var editCmd = &cobra.Command{
+	Use:     "edit <extension id>",
+	Short:   "Edits an extension update payload in $EDITOR and applies the changes",
+	PreRunE: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := extensionapi.Get(extensionapi.GetParams{
+			API:         ecctl.Get().API,
+			ExtensionID: args[0],
+		})
+		if err != nil {
+			return err
+		}
+
+		var params extensionapi.UpdateParams
+		format, _ := cmd.Flags().GetString("payload-format")
+		changed, err := cmdutil.EditPayload(cmdutil.EditPayloadParams{
+			Payload: extensionapi.NewUpdateRequestFromGet(res),
+			Format:  format,
+			Output:  ecctl.Get().Config.OutputDevice,
+			Decode: func(data []byte) error {
+				var p extensionapi.UpdateParams
+				if err := cmdutil.DecodeStrict(data, &p); err != nil {
+					return err
+				}
+				p.API, p.ExtensionID = ecctl.Get().API, args[0]
+				if err := p.Validate(); err != nil {
+					return err
+				}
+				params = p
+				return nil
+			},
+		})
+		if err != nil || !changed {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		var msg = fmt.Sprintf("Do you want to apply the changes to extension %s? [y/n]: ", args[0])
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		updateRes, err := extensionapi.Update(params)
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("", updateRes)
+	},
+}
+
+func init() {
+	initEditFlags()
+}
+
+func initEditFlags() {
+	Command.AddCommand(editCmd)
+	editCmd.Flags().String("payload-format", cmdutil.PayloadFormatJSON, cmdutil.PayloadFormatFlagMessage)
+}
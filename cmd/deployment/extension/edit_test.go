@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentextension
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_editCmd(t *testing.T) {
+	// The force flag is a persistent flag of the root command.
+	if Command.PersistentFlags().Lookup("force") == nil {
+		Command.PersistentFlags().Bool("force", false, "")
+	}
+
+	var succeedResp = new(models.Extension)
+	if err := succeedResp.UnmarshalBinary(updateRawResp); err != nil {
+		t.Fatal(err)
+	}
+
+	updateJSONOutput, err := json.MarshalIndent(succeedResp, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newGetResponse := func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Method: "GET",
+				Path:   "/api/v1/deployments/extensions/2649887448",
+				Host:   api.DefaultMockHost,
+				Query: url.Values{
+					"include_deployments": []string{"false"},
+				},
+			},
+			mock.NewByteBody(updatePayloadRawResp),
+		)
+	}
+
+	tests := []struct {
+		name   string
+		editor string
+		args   testutils.Args
+		want   testutils.Assertion
+	}{
+		{
+			name:   "fails when the edited payload is invalid",
+			editor: `sed -i s/"bundle"/""/`,
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", "2649887448"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newGetResponse()}},
+			},
+			want: testutils.Assertion{
+				Err: "the edited payload is invalid: invalid extension update params: 1 error occurred:\n\t* an extension type is required for this operation\n\n",
+			},
+		},
+		{
+			name:   "shows the diff and updates the extension",
+			editor: `sed -i s/"hello"/"bye"/`,
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", "2649887448", "--force"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newGetResponse(),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "POST",
+								Path:   "/api/v1/deployments/extensions/2649887448",
+								Body:   mock.NewStringBody(`{"description":"bye","extension_type":"bundle","name":"mybundle","version":"*"}` + "\n"),
+								Host:   api.DefaultMockHost,
+							},
+							mock.NewByteBody(updateRawResp),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "--- current\n+++ edited\n@@ -1,5 +1,5 @@\n {\n-  \"description\": \"hello\",\n+  \"description\": \"bye\",\n" +
+					"   \"extension_type\": \"bundle\",\n   \"name\": \"mybundle\",\n   \"version\": \"*\"\n" +
+					string(updateJSONOutput) + "\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", "")
+			t.Setenv("EDITOR", tt.editor)
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initEditFlags()
+		})
+	}
+}
@@ -0,0 +1,340 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+const healthLong = `Reports the health of a deployment and each of its resources, including any
+pending or failed plans, unhealthy instances and instances in maintenance mode.
+
+The command exits with a non-zero code when the deployment isn't healthy, so it
+can be used to gate CI/CD pipelines:
+
+  0  The deployment is healthy.
+  1  The deployment is unhealthy.
+  2  A plan is in progress.
+  3  The last plan of one or more resources failed.
+  4  One or more instances are in maintenance mode.
+
+When more than one condition applies, the exit code of the most severe one is
+returned. Maintenance mode is the least severe condition, so it never masks an
+unhealthy deployment or a pending or failed plan.`
+
+const healthExample = `## Check the health of a deployment.
+$ ecctl deployment health 5c17ad7c8df73206baa54b6e2829d9bc
+DEPLOYMENT ID                      NAME            STATUS
+5c17ad7c8df73206baa54b6e2829d9bc   my-deployment   healthy
+
+KIND            REF ID               STATUS    HEALTHY   PLAN   STEP
+elasticsearch   main-elasticsearch   started   true      ok     -
+kibana          main-kibana          started   true      ok     -
+[...]`
+
+// Deployment health statuses, ordered by increasing severity.
+const (
+	healthStatusHealthy     = "healthy"
+	healthStatusMaintenance = "maintenance"
+	healthStatusUnhealthy   = "unhealthy"
+	healthStatusPlanPending = "plan-pending"
+	healthStatusPlanFailed  = "plan-failed"
+)
+
+// Resource plan states.
+const (
+	planStateOK      = "ok"
+	planStatePending = "pending"
+	planStateFailed  = "failed"
+)
+
+// healthReturnCodes maps each deployment health status to its exit code.
+var healthReturnCodes = map[string]int{
+	healthStatusHealthy:     0,
+	healthStatusUnhealthy:   1,
+	healthStatusPlanPending: 2,
+	healthStatusPlanFailed:  3,
+	healthStatusMaintenance: 4,
+}
+
+// healthSeverities maps each deployment health status to its severity, which
+// determines the status reported when more than one condition applies.
+var healthSeverities = map[string]int{
+	healthStatusHealthy:     0,
+	healthStatusMaintenance: 1,
+	healthStatusUnhealthy:   2,
+	healthStatusPlanPending: 3,
+	healthStatusPlanFailed:  4,
+}
+
+// deploymentHealth summarises the health of a deployment and its resources.
+type deploymentHealth struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Healthy   bool             `json:"healthy"`
+	Status    string           `json:"status"`
+	Resources []resourceHealth `json:"resources"`
+}
+
+// resourceHealth summarises the health of a deployment resource.
+type resourceHealth struct {
+	Kind        string           `json:"kind"`
+	RefID       string           `json:"ref_id"`
+	Version     string           `json:"version,omitempty"`
+	Status      string           `json:"status"`
+	Healthy     bool             `json:"healthy"`
+	Plan        string           `json:"plan"`
+	PendingStep string           `json:"pending_step,omitempty"`
+	FailedStep  string           `json:"failed_step,omitempty"`
+	Instances   []instanceHealth `json:"instances"`
+}
+
+// instanceHealth summarises the health of a resource instance.
+type instanceHealth struct {
+	Name        string `json:"name"`
+	Zone        string `json:"zone"`
+	Healthy     bool   `json:"healthy"`
+	Running     bool   `json:"running"`
+	Maintenance bool   `json:"maintenance"`
+}
+
+// healthError is returned when the deployment isn't healthy, its return code
+// depends on the deployment's health status.
+type healthError struct {
+	id     string
+	status string
+}
+
+func (e healthError) Error() string {
+	return fmt.Sprintf("deployment %s is not healthy: %s", e.id, e.status)
+}
+
+// ReturnCode returns the exit code for the deployment health status.
+func (e healthError) ReturnCode() int { return healthReturnCodes[e.status] }
+
+var healthCmd = &cobra.Command{
+	Use:     "health <deployment id>",
+	Short:   "Reports the health of a deployment and its resources",
+	Long:    healthLong,
+	Example: healthExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:       true,
+				ShowPlanLogs:    true,
+				ShowPlanHistory: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		health := newDeploymentHealth(res)
+		if err := ecctl.Get().Formatter.Format("deployment/health", health); err != nil {
+			return err
+		}
+
+		if health.Status != healthStatusHealthy {
+			return healthError{id: health.ID, status: health.Status}
+		}
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(healthCmd)
+}
+
+// newDeploymentHealth builds the health summary of a deployment, obtaining its
+// status from the most severe condition found in any of its resources.
+func newDeploymentHealth(res *models.DeploymentGetResponse) deploymentHealth {
+	health := deploymentHealth{
+		Healthy:   res.Healthy != nil && *res.Healthy,
+		Status:    healthStatusHealthy,
+		Resources: getResourcesHealth(res.Resources),
+	}
+	if res.ID != nil {
+		health.ID = *res.ID
+	}
+	if res.Name != nil {
+		health.Name = *res.Name
+	}
+
+	if !health.Healthy {
+		health.escalate(healthStatusUnhealthy)
+	}
+
+	for _, r := range health.Resources {
+		switch r.Plan {
+		case planStateFailed:
+			health.escalate(healthStatusPlanFailed)
+		case planStatePending:
+			health.escalate(healthStatusPlanPending)
+		}
+		if !r.Healthy {
+			health.escalate(healthStatusUnhealthy)
+		}
+		for _, i := range r.Instances {
+			if i.Maintenance {
+				health.escalate(healthStatusMaintenance)
+			}
+		}
+	}
+
+	return health
+}
+
+// escalate sets the deployment health status to the specified one when it's
+// more severe than the current status.
+func (h *deploymentHealth) escalate(status string) {
+	if healthSeverities[status] > healthSeverities[h.Status] {
+		h.Status = status
+	}
+}
+
+// getResourcesHealth returns the health of all the deployment resources.
+func getResourcesHealth(res *models.DeploymentResources) []resourceHealth {
+	var result = make([]resourceHealth, 0)
+	if res == nil {
+		return result
+	}
+
+	for _, r := range res.Elasticsearch {
+		if r.Info != nil {
+			result = append(result, newResourceHealth(
+				"elasticsearch", r.RefID, r.Info.Status, r.Info.Healthy, r.Info.Topology, r.Info.PlanInfo,
+			))
+		}
+	}
+	for _, r := range res.Kibana {
+		if r.Info != nil {
+			result = append(result, newResourceHealth(
+				"kibana", r.RefID, r.Info.Status, r.Info.Healthy, r.Info.Topology, r.Info.PlanInfo,
+			))
+		}
+	}
+	for _, r := range res.Apm {
+		if r.Info != nil {
+			result = append(result, newResourceHealth(
+				"apm", r.RefID, r.Info.Status, r.Info.Healthy, r.Info.Topology, r.Info.PlanInfo,
+			))
+		}
+	}
+	for _, r := range res.IntegrationsServer {
+		if r.Info != nil {
+			result = append(result, newResourceHealth(
+				"integrations_server", r.RefID, r.Info.Status, r.Info.Healthy, r.Info.Topology, r.Info.PlanInfo,
+			))
+		}
+	}
+	for _, r := range res.Appsearch {
+		if r.Info != nil {
+			result = append(result, newResourceHealth(
+				"appsearch", r.RefID, r.Info.Status, r.Info.Healthy, r.Info.Topology, r.Info.PlanInfo,
+			))
+		}
+	}
+	for _, r := range res.EnterpriseSearch {
+		if r.Info != nil {
+			result = append(result, newResourceHealth(
+				"enterprise_search", r.RefID, r.Info.Status, r.Info.Healthy, r.Info.Topology, r.Info.PlanInfo,
+			))
+		}
+	}
+
+	return result
+}
+
+// newResourceHealth builds the health summary of a single resource. When the
+// resource has a pending plan, its plan state is pending. Otherwise, the plan
+// state is failed when the last plan attempt wasn't healthy.
+func newResourceHealth(kind string, refID *string, status *string, healthy *bool, topology *models.ClusterTopologyInfo, planInfo interface{}) resourceHealth {
+	var health = resourceHealth{
+		Kind:      kind,
+		Healthy:   healthy != nil && *healthy,
+		Plan:      planStateOK,
+		Instances: make([]instanceHealth, 0),
+	}
+	if refID != nil {
+		health.RefID = *refID
+	}
+	if status != nil {
+		health.Status = *status
+	}
+
+	// The plan info types are always encodable, so a resource with no plans
+	// is used when its plan info can't be decoded.
+	plans, err := planinfo.Decode(kind, health.RefID, planInfo)
+	if err != nil {
+		plans = &planinfo.Resource{Kind: kind, RefID: health.RefID}
+	}
+	health.Version = plans.Version()
+
+	var last = plans.Last()
+	switch {
+	case plans.Pending != nil:
+		health.Plan = planStatePending
+		health.PendingStep = getPendingPlanStepName(plans.Pending.PlanAttemptLog)
+	case last != nil && last.Healthy != nil && !*last.Healthy:
+		health.Plan = planStateFailed
+		health.FailedStep = last.FailedStep()
+		if health.FailedStep == "" {
+			health.FailedStep = "unknown"
+		}
+	}
+
+	if topology == nil {
+		return health
+	}
+
+	for _, i := range topology.Instances {
+		var instance = instanceHealth{
+			Zone:        i.Zone,
+			Healthy:     i.Healthy != nil && *i.Healthy,
+			Running:     i.ServiceRunning != nil && *i.ServiceRunning,
+			Maintenance: i.MaintenanceMode != nil && *i.MaintenanceMode,
+		}
+		if i.InstanceName != nil {
+			instance.Name = *i.InstanceName
+		}
+		health.Instances = append(health.Instances, instance)
+	}
+
+	return health
+}
+
+// getPendingPlanStepName returns the ID of the step that the pending plan is
+// currently executing.
+func getPendingPlanStepName(log []*models.ClusterPlanStepInfo) string {
+	if n := len(log); n > 0 && log[n-1].StepID != nil {
+		return *log[n-1].StepID
+	}
+	return "unknown"
+}
@@ -0,0 +1,307 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+func newPlanStep(id, status string) *models.ClusterPlanStepInfo {
+	return &models.ClusterPlanStepInfo{StepID: ec.String(id), Status: ec.String(status)}
+}
+
+func newHealthTestDeployment(esPlans *models.ElasticsearchClusterPlansInfo, kbHealthy bool, maintenance bool) *models.DeploymentGetResponse {
+	return &models.DeploymentGetResponse{
+		ID:      ec.String("5c17ad7c8df73206baa54b6e2829d9bc"),
+		Name:    ec.String("my-deployment"),
+		Healthy: ec.Bool(kbHealthy),
+		Resources: &models.DeploymentResources{
+			Elasticsearch: []*models.ElasticsearchResourceInfo{{
+				RefID: ec.String("main-elasticsearch"),
+				Info: &models.ElasticsearchClusterInfo{
+					Status:   ec.String("started"),
+					Healthy:  ec.Bool(true),
+					PlanInfo: esPlans,
+					Topology: &models.ClusterTopologyInfo{Instances: []*models.ClusterInstanceInfo{{
+						InstanceName:    ec.String("instance-0000000000"),
+						Zone:            "us-east-1a",
+						Healthy:         ec.Bool(true),
+						ServiceRunning:  ec.Bool(true),
+						MaintenanceMode: ec.Bool(maintenance),
+					}}},
+				},
+			}},
+			Kibana: []*models.KibanaResourceInfo{{
+				RefID: ec.String("main-kibana"),
+				Info: &models.KibanaClusterInfo{
+					Status:  ec.String("started"),
+					Healthy: ec.Bool(kbHealthy),
+					PlanInfo: &models.KibanaClusterPlansInfo{
+						Current: &models.KibanaClusterPlanInfo{Healthy: ec.Bool(true)},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func Test_newDeploymentHealth(t *testing.T) {
+	healthyPlans := &models.ElasticsearchClusterPlansInfo{
+		Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+	}
+	tests := []struct {
+		name string
+		res  *models.DeploymentGetResponse
+		want deploymentHealth
+	}{
+		{
+			name: "healthy deployment",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true), Plan: &models.ElasticsearchClusterPlan{
+					Elasticsearch: &models.ElasticsearchConfiguration{Version: "8.15.0"},
+				}},
+			}, true, false),
+			want: deploymentHealth{
+				ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "my-deployment",
+				Healthy: true, Status: "healthy",
+				Resources: []resourceHealth{
+					{Kind: "elasticsearch", RefID: "main-elasticsearch", Version: "8.15.0", Status: "started", Healthy: true, Plan: "ok",
+						Instances: []instanceHealth{{
+							Name: "instance-0000000000", Zone: "us-east-1a", Healthy: true, Running: true,
+						}},
+					},
+					{Kind: "kibana", RefID: "main-kibana", Status: "started", Healthy: true, Plan: "ok", Instances: []instanceHealth{}},
+				},
+			},
+		},
+		{
+			name: "instances in maintenance mode",
+			res:  newHealthTestDeployment(healthyPlans, true, true),
+			want: deploymentHealth{
+				ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "my-deployment",
+				Healthy: true, Status: "maintenance",
+				Resources: []resourceHealth{
+					{Kind: "elasticsearch", RefID: "main-elasticsearch", Status: "started", Healthy: true, Plan: "ok",
+						Instances: []instanceHealth{{
+							Name: "instance-0000000000", Zone: "us-east-1a", Healthy: true, Running: true, Maintenance: true,
+						}},
+					},
+					{Kind: "kibana", RefID: "main-kibana", Status: "started", Healthy: true, Plan: "ok", Instances: []instanceHealth{}},
+				},
+			},
+		},
+		{
+			name: "unhealthy deployment",
+			res:  newHealthTestDeployment(healthyPlans, false, false),
+			want: deploymentHealth{
+				ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "my-deployment",
+				Status: "unhealthy",
+				Resources: []resourceHealth{
+					{Kind: "elasticsearch", RefID: "main-elasticsearch", Status: "started", Healthy: true, Plan: "ok",
+						Instances: []instanceHealth{{
+							Name: "instance-0000000000", Zone: "us-east-1a", Healthy: true, Running: true,
+						}},
+					},
+					{Kind: "kibana", RefID: "main-kibana", Status: "started", Plan: "ok", Instances: []instanceHealth{}},
+				},
+			},
+		},
+		{
+			name: "failed plan takes precedence over maintenance",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+				History: []*models.ElasticsearchClusterPlanInfo{
+					{Healthy: ec.Bool(false), PlanAttemptLog: []*models.ClusterPlanStepInfo{
+						newPlanStep("allocate-instances", "error"),
+					}},
+				},
+			}, true, true),
+			want: deploymentHealth{
+				ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "my-deployment",
+				Healthy: true, Status: "plan-failed",
+				Resources: []resourceHealth{
+					{Kind: "elasticsearch", RefID: "main-elasticsearch", Status: "started", Healthy: true,
+						Plan: "failed", FailedStep: "allocate-instances",
+						Instances: []instanceHealth{{
+							Name: "instance-0000000000", Zone: "us-east-1a", Healthy: true, Running: true, Maintenance: true,
+						}},
+					},
+					{Kind: "kibana", RefID: "main-kibana", Status: "started", Healthy: true, Plan: "ok", Instances: []instanceHealth{}},
+				},
+			},
+		},
+		{
+			name: "plan in progress takes precedence over unhealthy",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+				Pending: &models.ElasticsearchClusterPlanInfo{PlanAttemptLog: []*models.ClusterPlanStepInfo{
+					newPlanStep("plan-started", "success"),
+					newPlanStep("rolling-upgrade", "pending"),
+				}},
+			}, false, false),
+			want: deploymentHealth{
+				ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "my-deployment",
+				Status: "plan-pending",
+				Resources: []resourceHealth{
+					{Kind: "elasticsearch", RefID: "main-elasticsearch", Status: "started", Healthy: true,
+						Plan: "pending", PendingStep: "rolling-upgrade",
+						Instances: []instanceHealth{{
+							Name: "instance-0000000000", Zone: "us-east-1a", Healthy: true, Running: true,
+						}},
+					},
+					{Kind: "kibana", RefID: "main-kibana", Status: "started", Plan: "ok", Instances: []instanceHealth{}},
+				},
+			},
+		},
+		{
+			name: "failed plan",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+				History: []*models.ElasticsearchClusterPlanInfo{
+					{Healthy: ec.Bool(true)},
+					{Healthy: ec.Bool(false), PlanAttemptLog: []*models.ClusterPlanStepInfo{
+						newPlanStep("plan-started", "success"),
+						newPlanStep("allocate-instances", "error"),
+						newPlanStep("plan-completed", "error"),
+					}},
+				},
+			}, true, false),
+			want: deploymentHealth{
+				ID: "5c17ad7c8df73206baa54b6e2829d9bc", Name: "my-deployment",
+				Healthy: true, Status: "plan-failed",
+				Resources: []resourceHealth{
+					{Kind: "elasticsearch", RefID: "main-elasticsearch", Status: "started", Healthy: true,
+						Plan: "failed", FailedStep: "allocate-instances",
+						Instances: []instanceHealth{{
+							Name: "instance-0000000000", Zone: "us-east-1a", Healthy: true, Running: true,
+						}},
+					},
+					{Kind: "kibana", RefID: "main-kibana", Status: "started", Healthy: true, Plan: "ok", Instances: []instanceHealth{}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newDeploymentHealth(tt.res))
+		})
+	}
+}
+
+func Test_healthError(t *testing.T) {
+	var err error = healthError{id: "5c17ad7c8df73206baa54b6e2829d9bc", status: "plan-failed"}
+	ret, ok := err.(ecctl.ReturnCodeError)
+	assert.True(t, ok)
+	assert.Equal(t, 3, ret.ReturnCode())
+	assert.EqualError(t, err, "deployment 5c17ad7c8df73206baa54b6e2829d9bc is not healthy: plan-failed")
+}
+
+func Test_healthCmd(t *testing.T) {
+	const id = "5c17ad7c8df73206baa54b6e2829d9bc"
+	newGetResponse := func(res *models.DeploymentGetResponse) mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Method: "GET",
+				Path:   "/api/v1/deployments/" + id,
+				Host:   api.DefaultMockHost,
+				Query: url.Values{
+					"convert_legacy_plans": {"false"},
+					"show_metadata":        {"false"},
+					"show_plan_defaults":   {"false"},
+					"show_plan_history":    {"true"},
+					"show_plan_logs":       {"true"},
+					"show_plans":           {"true"},
+					"show_settings":        {"false"},
+					"show_system_alerts":   {"5"},
+				},
+			},
+			mock.NewStructBody(res),
+		)
+	}
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "reports a healthy deployment",
+			args: testutils.Args{
+				Cmd:  healthCmd,
+				Args: []string{"health", id},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{newGetResponse(newHealthTestDeployment(
+						&models.ElasticsearchClusterPlansInfo{
+							Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+						}, true, false,
+					))},
+				},
+			},
+			want: testutils.Assertion{Stdout: "DEPLOYMENT ID                      NAME            STATUS\n" +
+				"5c17ad7c8df73206baa54b6e2829d9bc   my-deployment   healthy\n\n" +
+				"KIND            REF ID               STATUS    HEALTHY   PLAN   STEP\n" +
+				"elasticsearch   main-elasticsearch   started   true      ok     -\n" +
+				"kibana          main-kibana          started   true      ok     -\n\n" +
+				"INSTANCE              REF ID               ZONE         HEALTHY   RUNNING   MAINTENANCE\n" +
+				"instance-0000000000   main-elasticsearch   us-east-1a   true      true      false\n",
+			},
+		},
+		{
+			name: "reports an unhealthy deployment",
+			args: testutils.Args{
+				Cmd:  healthCmd,
+				Args: []string{"health", id},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{newGetResponse(&models.DeploymentGetResponse{
+						ID:        ec.String(id),
+						Name:      ec.String("my-deployment"),
+						Healthy:   ec.Bool(false),
+						Resources: &models.DeploymentResources{},
+					})},
+				},
+			},
+			want: testutils.Assertion{
+				Err: "deployment 5c17ad7c8df73206baa54b6e2829d9bc is not healthy: unhealthy",
+				Stdout: `{
+  "id": "5c17ad7c8df73206baa54b6e2829d9bc",
+  "name": "my-deployment",
+  "healthy": false,
+  "status": "unhealthy",
+  "resources": []
+}
+`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdkibana
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/spf13/cobra"
+
+	cmddeploymentusersettings "github.com/elastic/ecctl/cmd/deployment/usersettings"
+)
+
+// Command is the deployment kibana subcommand
+var Command = &cobra.Command{
+	Use:     "kibana",
+	Short:   "Manages Kibana resources",
+	PreRunE: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+func init() {
+	Command.AddCommand(cmddeploymentusersettings.NewCommand(util.Kibana))
+}
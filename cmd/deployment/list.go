@@ -18,20 +18,106 @@
 package cmddeployment
 
 import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/spf13/cobra"
 
 	"github.com/elastic/ecctl/pkg/ecctl"
 )
 
+const (
+	esPlanField       = "resources.elasticsearch.info.plan_info.current.plan"
+	esPlanHistoryTime = "resources.elasticsearch.info.plan_info.history.attempt_start_time"
+)
+
+// deploymentListResponse is the deployments list response with the tags of
+// each deployment, which are not part of the list API response.
+type deploymentListResponse struct {
+	Deployments []deploymentListing `json:"deployments"`
+
+	// ShowTags is used by the text template to display the tags column.
+	ShowTags bool `json:"-"`
+}
+
+// deploymentListing is a deployments list response item with its tags.
+type deploymentListing struct {
+	*models.DeploymentsListingData
+	Tags []*models.MetadataItem `json:"tags,omitempty"`
+}
+
+// listFilterFlags are the flags which filter the listed deployments.
+var listFilterFlags = []string{
+	"name", "version", "healthy", "tag", "kind", "template", "created-after",
+}
+
+// listKinds are the resource kinds which can be used to filter deployments.
+var listKinds = []string{
+	"elasticsearch", "kibana", "apm", "integrations_server", "enterprise_search", "appsearch",
+}
+
+// queryStringReserved contains the Lucene query string reserved characters,
+// excluding the * and ? wildcards.
+var queryStringReserved = `+-=&|><!(){}[]^"~:\/ `
+
+const listLong = `Lists the platform's deployments. When any of the filtering flags is specified, the
+deployments are obtained through a deployment search, with all the filters combined,
+and all the matches are returned. The output is the same with and without filters.
+
+  * --name matches the deployment name with a glob pattern, such as "prod-*".
+  * --version matches the Elasticsearch version.
+  * --healthy matches the deployment health, only when specified: --healthy lists
+    the healthy deployments and --healthy=false the unhealthy ones.
+  * --tag matches a key=value metadata tag, and can be specified multiple times.
+  * --kind matches the deployments containing a resource kind, and can be specified
+    multiple times.
+  * --template matches the deployment template ID.
+  * --created-after matches the deployments created after a date (2006-01-02), a
+    timestamp (2006-01-02T15:04:05Z) or a duration relative to now, such as 72h.
+    Since deployments have no creation time, it's approximated with the start time
+    of their oldest Elasticsearch plan attempt. Deployments whose plan history has
+    been pruned are matched when the oldest remaining attempt started after it.
+
+The deployment tags are obtained with a deployment search and displayed in the TAGS
+column, unless --hide-tags is specified. When no filters are specified and the tags
+can't be obtained, the deployments are listed without them.`
+
+const listExample = `## List the unhealthy production deployments running Kibana.
+$ ecctl deployment list --name "prod-*" --healthy=false --kind kibana
+
+## List the deployments tagged with team=search created in the last week.
+$ ecctl deployment list --tag team=search --created-after 168h
+
+## List all the deployments without their tags.
+$ ecctl deployment list --hide-tags`
+
 var listCmd = &cobra.Command{
-	Use:     "list",
+	Use:     "list [--name <glob>] [--version <version>] [--healthy] [--tag <key=value>] [--kind <kind>] [--template <id>] [--created-after <date>] [--hide-tags]",
 	Short:   "Lists the platform's deployments",
+	Long:    listLong,
+	Example: listExample,
 	PreRunE: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		res, err := deploymentapi.List(deploymentapi.ListParams{
-			API: ecctl.Get().API,
-		})
+		sr, err := newListSearchRequest(cmd, time.Now())
+		if err != nil {
+			return err
+		}
+
+		hideTags, _ := cmd.Flags().GetBool("hide-tags")
+		var showTags = !hideTags
+		var res *deploymentListResponse
+		if sr == nil {
+			res, err = listDeployments(showTags, cmd.ErrOrStderr())
+		} else {
+			res, err = searchListDeployments(sr, showTags)
+		}
 		if err != nil {
 			return err
 		}
@@ -41,5 +127,288 @@ var listCmd = &cobra.Command{
 }
 
 func init() {
+	initListFlags()
+}
+
+func initListFlags() {
 	Command.AddCommand(listCmd)
+	listCmd.Flags().String("name", "", "Optional glob pattern the deployment name must match")
+	listCmd.Flags().String("version", "", "Optional Elasticsearch version the deployments must run")
+	listCmd.Flags().Bool("healthy", false, "Optional deployment health to match, only filters the deployments when specified")
+	listCmd.Flags().StringArray("tag", nil, "Optional key=value metadata tag the deployments must have, can be specified multiple times")
+	listCmd.Flags().StringSlice("kind", nil, fmt.Sprintf("Optional resource kind the deployments must contain (%s)", strings.Join(listKinds, ", ")))
+	listCmd.Flags().String("template", "", "Optional deployment template ID the deployments must be based on")
+	listCmd.Flags().String("created-after", "", "Optional date, timestamp or duration relative to now the deployments must have been created after, approximated from the plan history")
+	listCmd.Flags().Bool("hide-tags", false, "Hides the deployment tags, which are otherwise obtained with a deployment search")
+	listCmd.Flags().Int32("size", 500, "Defines the size per request when any of the filtering flags is specified")
+}
+
+// newListSearchRequest translates the filtering flags into a deployment search
+// request. When no filtering flags are specified, nil is returned.
+func newListSearchRequest(cmd *cobra.Command, now time.Time) (*models.SearchRequest, error) {
+	var changed bool
+	for _, name := range listFilterFlags {
+		if cmd.Flags().Changed(name) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	var merr = multierror.NewPrefixed("invalid list filters")
+	var query models.BoolQuery
+	if name, _ := cmd.Flags().GetString("name"); name != "" {
+		query.Filter = append(query.Filter, &models.QueryContainer{
+			QueryString: &models.QueryStringQuery{
+				AllowLeadingWildcard: ec.Bool(true),
+				DefaultField:         "name.keyword",
+				Query:                ec.String(escapeQueryString(name)),
+			},
+		})
+	}
+
+	if version, _ := cmd.Flags().GetString("version"); version != "" {
+		query.Filter = append(query.Filter, newTermQuery(esPlanField+".elasticsearch.version", version))
+	}
+
+	if cmd.Flags().Changed("healthy") {
+		healthy, _ := cmd.Flags().GetBool("healthy")
+		query.Filter = append(query.Filter, newTermQuery("healthy", strconv.FormatBool(healthy)))
+	}
+
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	for _, tag := range tags {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			merr = merr.Append(fmt.Errorf(`--tag: "%s" must be in the key=value format`, tag))
+			continue
+		}
+		query.Filter = append(query.Filter, &models.QueryContainer{
+			Nested: &models.NestedQuery{
+				Path: ec.String("metadata.tags"),
+				Query: &models.QueryContainer{Bool: &models.BoolQuery{Filter: []*models.QueryContainer{
+					newTermQuery("metadata.tags.key", kv[0]),
+					newTermQuery("metadata.tags.value", kv[1]),
+				}}},
+			},
+		})
+	}
+
+	kinds, _ := cmd.Flags().GetStringSlice("kind")
+	for _, kind := range kinds {
+		if !containsString(listKinds, kind) {
+			merr = merr.Append(fmt.Errorf(`--kind: "%s" is not a valid resource kind (%s)`,
+				kind, strings.Join(listKinds, ", "),
+			))
+			continue
+		}
+		query.Filter = append(query.Filter, &models.QueryContainer{
+			Exists: &models.ExistsQuery{Field: ec.String(fmt.Sprintf("resources.%s.id", kind))},
+		})
+	}
+
+	if template, _ := cmd.Flags().GetString("template"); template != "" {
+		query.Filter = append(query.Filter, newTermQuery(esPlanField+".deployment_template.id", template))
+	}
+
+	if createdAfter, _ := cmd.Flags().GetString("created-after"); createdAfter != "" {
+		t, err := parseCreatedAfter(createdAfter, now)
+		if err != nil {
+			merr = merr.Append(fmt.Errorf("--created-after: %w", err))
+		} else {
+			// Deployments created after the time have no plan attempts
+			// which started before it.
+			query.MustNot = append(query.MustNot, &models.QueryContainer{
+				Range: map[string]models.RangeQuery{
+					esPlanHistoryTime: {Lt: t.UTC().Format(time.RFC3339)},
+				},
+			})
+		}
+	}
+
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	size, _ := cmd.Flags().GetInt32("size")
+	return &models.SearchRequest{
+		Query: &models.QueryContainer{Bool: &query},
+		Size:  size,
+		Sort:  []interface{}{"id"},
+	}, nil
+}
+
+// searchAllDeployments uses a cursor to return all the matches of the search
+// request.
+func searchAllDeployments(sr *models.SearchRequest) (*models.DeploymentsSearchResponse, error) {
+	var result = models.DeploymentsSearchResponse{
+		Deployments: []*models.DeploymentSearchResponse{},
+		ReturnCount: ec.Int32(0),
+	}
+	for {
+		res, err := deploymentapi.Search(deploymentapi.SearchParams{
+			API:     ecctl.Get().API,
+			Request: sr,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result.Deployments = append(result.Deployments, res.Deployments...)
+		result.ReturnCount = ec.Int32(int32(len(result.Deployments)))
+		result.MatchCount = *result.ReturnCount
+
+		if res.ReturnCount == nil || *res.ReturnCount == 0 || res.Cursor == "" {
+			break
+		}
+		sr.Cursor = res.Cursor
+	}
+
+	return &result, nil
+}
+
+// listDeployments lists the deployments. When showTags is set, the deployments
+// are obtained with a single deployment search to include their tags, falling
+// back to the list API without tags when the search fails.
+func listDeployments(showTags bool, w io.Writer) (*deploymentListResponse, error) {
+	if showTags {
+		res, err := searchListDeployments(&models.SearchRequest{
+			Query: &models.QueryContainer{MatchAll: struct{}{}},
+			Size:  500,
+			Sort:  []interface{}{"id"},
+		}, true)
+		if err == nil {
+			return res, nil
+		}
+		_, _ = fmt.Fprintf(w, "Warning: failed obtaining the deployment tags, listing the deployments without them: %s\n",
+			strings.Join(strings.Fields(err.Error()), " "),
+		)
+	}
+
+	res, err := deploymentapi.List(deploymentapi.ListParams{
+		API: ecctl.Get().API,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result = deploymentListResponse{
+		Deployments: make([]deploymentListing, 0, len(res.Deployments)),
+	}
+	for _, d := range res.Deployments {
+		result.Deployments = append(result.Deployments, deploymentListing{DeploymentsListingData: d})
+	}
+
+	return &result, nil
+}
+
+// searchListDeployments returns all the deployments matching the search
+// request as a deployments list response, including their tags when showTags
+// is set.
+func searchListDeployments(sr *models.SearchRequest, showTags bool) (*deploymentListResponse, error) {
+	res, err := searchAllDeployments(sr)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = deploymentListResponse{
+		Deployments: make([]deploymentListing, 0, len(res.Deployments)),
+		ShowTags:    showTags,
+	}
+	for _, d := range res.Deployments {
+		var listing = deploymentListing{DeploymentsListingData: newDeploymentsListingData(d)}
+		if showTags && d.Metadata != nil {
+			listing.Tags = d.Metadata.Tags
+		}
+		result.Deployments = append(result.Deployments, listing)
+	}
+
+	return &result, nil
+}
+
+// newDeploymentsListingData maps a deployment search result to the deployment
+// list API format.
+func newDeploymentsListingData(d *models.DeploymentSearchResponse) *models.DeploymentsListingData {
+	var listing = models.DeploymentsListingData{
+		ID:        d.ID,
+		Name:      d.Name,
+		Resources: make([]*models.DeploymentResource, 0),
+	}
+	if d.Resources == nil {
+		return &listing
+	}
+
+	var add = func(kind string, id, refID, region *string) *models.DeploymentResource {
+		var res = models.DeploymentResource{Kind: ec.String(kind), ID: id, RefID: refID, Region: region}
+		listing.Resources = append(listing.Resources, &res)
+		return &res
+	}
+
+	for _, r := range d.Resources.Elasticsearch {
+		res := add("elasticsearch", r.ID, r.RefID, r.Region)
+		if r.Info != nil && r.Info.Metadata != nil {
+			res.CloudID = r.Info.Metadata.CloudID
+		}
+	}
+	for _, r := range d.Resources.Kibana {
+		add("kibana", r.ID, r.RefID, r.Region)
+	}
+	for _, r := range d.Resources.Apm {
+		add("apm", r.ID, r.RefID, r.Region)
+	}
+	for _, r := range d.Resources.IntegrationsServer {
+		add("integrations_server", r.ID, r.RefID, r.Region)
+	}
+	for _, r := range d.Resources.EnterpriseSearch {
+		add("enterprise_search", r.ID, r.RefID, r.Region)
+	}
+	for _, r := range d.Resources.Appsearch {
+		add("appsearch", r.ID, r.RefID, r.Region)
+	}
+
+	return &listing
+}
+
+func newTermQuery(field, value string) *models.QueryContainer {
+	return &models.QueryContainer{
+		Term: map[string]models.TermQuery{field: {Value: ec.String(value)}},
+	}
+}
+
+// parseCreatedAfter parses a date, an RFC3339 timestamp or a duration which is
+// subtracted from now.
+func parseCreatedAfter(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf(`"%s" is not a valid date, timestamp or duration`, s)
+}
+
+// escapeQueryString escapes the query string reserved characters, keeping the
+// * and ? wildcards.
+func escapeQueryString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(queryStringReserved, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,275 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_newListSearchRequest(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		args []string
+		want string
+		err  string
+	}{
+		{
+			name: "returns nil when no filters are set",
+		},
+		{
+			name: "translates all the filters",
+			args: []string{
+				"--name=prod-*", "--version=8.15.0", "--healthy=false",
+				"--tag=team=search", "--kind=kibana", "--template=aws-io-optimized-v2",
+				"--created-after=72h",
+			},
+			want: `{"query":{"bool":{"filter":[` +
+				`{"query_string":{"allow_leading_wildcard":true,"default_field":"name.keyword","query":"prod\\-*"}},` +
+				`{"term":{"resources.elasticsearch.info.plan_info.current.plan.elasticsearch.version":{"value":"8.15.0"}}},` +
+				`{"term":{"healthy":{"value":"false"}}},` +
+				`{"nested":{"path":"metadata.tags","query":{"bool":{"filter":[` +
+				`{"term":{"metadata.tags.key":{"value":"team"}}},` +
+				`{"term":{"metadata.tags.value":{"value":"search"}}}]}}}},` +
+				`{"exists":{"field":"resources.kibana.id"}},` +
+				`{"term":{"resources.elasticsearch.info.plan_info.current.plan.deployment_template.id":{"value":"aws-io-optimized-v2"}}}],` +
+				`"must_not":[{"range":{"resources.elasticsearch.info.plan_info.history.attempt_start_time":{"lt":"2024-03-07T12:00:00Z"}}}]}},` +
+				`"size":500,"sort":["id"]}`,
+		},
+		{
+			name: "fails on invalid filters",
+			args: []string{"--tag=team", "--kind=beats", "--created-after=yesterday"},
+			err: "invalid list filters: 3 errors occurred:\n" +
+				"\t* --created-after: \"yesterday\" is not a valid date, timestamp or duration\n" +
+				"\t* --kind: \"beats\" is not a valid resource kind (elasticsearch, kibana, apm, integrations_server, enterprise_search, appsearch)\n" +
+				"\t* --tag: \"team\" must be in the key=value format\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().AddFlagSet(listCmd.Flags())
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			defer func() {
+				listCmd.ResetFlags()
+				initListFlags()
+			}()
+
+			got, err := newListSearchRequest(cmd, now)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+
+			b, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(b))
+		})
+	}
+}
+
+func Test_listCmd(t *testing.T) {
+	searchBody := func(cursor string) io.ReadCloser {
+		return mock.NewStructBody(models.SearchRequest{
+			Cursor: cursor,
+			Query: &models.QueryContainer{Bool: &models.BoolQuery{Filter: []*models.QueryContainer{
+				{Term: map[string]models.TermQuery{"healthy": {Value: ec.String("false")}}},
+			}}},
+			Size: 500,
+			Sort: []interface{}{"id"},
+		})
+	}
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "filters collect deployments using multiple search requests",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", "--healthy=false"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "json",
+					Responses: []mock.Response{
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "POST",
+								Path:   "/api/v1/deployments/_search",
+								Host:   api.DefaultMockHost,
+								Body:   searchBody(""),
+							},
+							mock.NewStructBody(models.DeploymentsSearchResponse{
+								Cursor:      "cursor1",
+								Deployments: []*models.DeploymentSearchResponse{{ID: ec.String("d1")}},
+								ReturnCount: ec.Int32(1),
+							}),
+						),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "POST",
+								Path:   "/api/v1/deployments/_search",
+								Host:   api.DefaultMockHost,
+								Body:   searchBody("cursor1"),
+							},
+							mock.NewStructBody(models.DeploymentsSearchResponse{
+								Deployments: []*models.DeploymentSearchResponse{},
+								ReturnCount: ec.Int32(0),
+							}),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: `{
+  "deployments": [
+    {
+      "id": "d1",
+      "name": null,
+      "resources": []
+    }
+  ]
+}
+`,
+			},
+		},
+		{
+			name: "lists the deployments without their tags",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", "--hide-tags"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						mock.New200Response(mock.NewStructBody(models.DeploymentsListResponse{
+							Deployments: []*models.DeploymentsListingData{
+								{ID: ec.String("d1"), Name: ec.String("first")},
+							},
+						})),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "ID   NAME    ELASTICSEARCH   KIBANA   APM   ENTERPRISE_SEARCH   APPSEARCH\n" +
+					"d1   first   d1              -        -     -                   -\n",
+			},
+		},
+		{
+			name: "lists the deployments with their tags using a single search",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "POST",
+								Path:   "/api/v1/deployments/_search",
+								Host:   api.DefaultMockHost,
+								Body: mock.NewStructBody(models.SearchRequest{
+									Query: &models.QueryContainer{MatchAll: struct{}{}},
+									Size:  500,
+									Sort:  []interface{}{"id"},
+								}),
+							},
+							mock.NewStructBody(models.DeploymentsSearchResponse{
+								Deployments: []*models.DeploymentSearchResponse{
+									{ID: ec.String("d1"), Name: ec.String("tagged"), Metadata: &models.DeploymentMetadata{
+										Tags: []*models.MetadataItem{{Key: ec.String("team"), Value: ec.String("search")}},
+									}, Resources: &models.DeploymentResources{
+										Kibana: []*models.KibanaResourceInfo{{ID: ec.String("k1")}},
+									}},
+									{ID: ec.String("d2"), Name: ec.String("untagged")},
+								},
+								ReturnCount: ec.Int32(2),
+							}),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "ID   NAME       ELASTICSEARCH   KIBANA   APM   ENTERPRISE_SEARCH   APPSEARCH   TAGS\n" +
+					"d1   tagged     d1              k1       -     -                   -           team=search\n" +
+					"d2   untagged   d2              -        -     -                   -           -\n",
+			},
+		},
+		{
+			name: "lists the deployments without their tags when the search fails",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses: []mock.Response{
+						mock.New500Response(mock.NewStringBody(`{"errors":[{"code":"search.failed","message":"search failed"}]}`)),
+						mock.New200Response(mock.NewStructBody(models.DeploymentsListResponse{
+							Deployments: []*models.DeploymentsListingData{
+								{ID: ec.String("d1"), Name: ec.String("first")},
+							},
+						})),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "ID   NAME    ELASTICSEARCH   KIBANA   APM   ENTERPRISE_SEARCH   APPSEARCH\n" +
+					"d1   first   d1              -        -     -                   -\n",
+				Stderr: "Warning: failed obtaining the deployment tags, listing the deployments without them: api error: 1 error occurred: * search.failed: search failed\n",
+			},
+		},
+		{
+			name: "fails on a search error",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", "--healthy=false"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					mock.SampleInternalError(),
+				}},
+			},
+			want: testutils.Assertion{Err: mock.MultierrorInternalError.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initListFlags()
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentplan
+
+import (
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+const diffExample = `## Show the changes between a plan attempt and the current plan.
+$ ecctl deployment plan diff 5c17ad7c8df73206baa54b6e2829d9bc --kind elasticsearch --from attempt-0000000003
+--- attempt-0000000003
++++ current
+@@ -3,7 +3,7 @@
+     {
+       "size": {
+         "resource": "memory",
+-        "value": 4096
++        "value": 8192
+       }
+[...]
+
+## Show the changes which the pending plan introduces.
+$ ecctl deployment plan diff 5c17ad7c8df73206baa54b6e2829d9bc --kind kibana --from current --to pending`
+
+var diffPlan = &cobra.Command{
+	Use:     "diff <deployment id> --kind <kind> --from <attempt> [--to current|pending|<attempt>]",
+	Short:   "Shows the changes between two plans of a deployment resource",
+	Example: diffExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resKind, _ := cmd.Flags().GetString("kind")
+		refID, _ := cmd.Flags().GetString("ref-id")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:       true,
+				ShowPlanHistory: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		plans, err := getResourcePlan(res, resKind, refID)
+		if err != nil {
+			return err
+		}
+
+		diff, err := diffPlanAttempts(plans, from, to)
+		if err != nil {
+			return err
+		}
+
+		if diff == "" {
+			diff = fmt.Sprintf("No changes between %s and %s\n", from, to)
+		}
+
+		_, err = fmt.Fprint(ecctl.Get().Config.OutputDevice, diff)
+		return err
+	},
+}
+
+func init() {
+	Command.AddCommand(diffPlan)
+	cmdutil.AddKindFlag(diffPlan, "Required", true)
+	diffPlan.MarkFlagRequired("kind")
+	diffPlan.Flags().String("ref-id", "", "Optional deployment RefId, if not set, the RefId will be auto-discovered")
+	diffPlan.Flags().String("from", "", "Required plan attempt ID or name to compare from, or one of current or pending")
+	diffPlan.MarkFlagRequired("from")
+	diffPlan.Flags().String("to", "current", "Plan attempt ID or name to compare to, or one of current or pending")
+}
+
+// diffPlanAttempts returns the unified diff between the plans of the specified
+// attempts, or an empty string when the plans are the same.
+func diffPlanAttempts(plans *planinfo.Resource, from, to string) (string, error) {
+	fromAttempt, err := findAttempt(plans, from)
+	if err != nil {
+		return "", err
+	}
+
+	toAttempt, err := findAttempt(plans, to)
+	if err != nil {
+		return "", err
+	}
+
+	return diffPlans(fromAttempt, toAttempt, from, to)
+}
+
+// diffPlans returns the unified diff between the plans of two attempts, using
+// the specified names as the diff's file names.
+func diffPlans(from, to *planinfo.Attempt, fromName, toName string) (string, error) {
+	fromPlan, err := indentedPlan(from)
+	if err != nil {
+		return "", err
+	}
+
+	toPlan, err := indentedPlan(to)
+	if err != nil {
+		return "", err
+	}
+
+	return cmdutil.UnifiedDiff(fromPlan, toPlan, fromName, toName)
+}
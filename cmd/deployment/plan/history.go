@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentplan
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+// Plan attempt results.
+const (
+	planResultSuccess    = "success"
+	planResultFailed     = "failed"
+	planResultInProgress = "in-progress"
+)
+
+// planHistoryEntry is a row of the plan history timeline.
+type planHistoryEntry struct {
+	Kind       string `json:"kind"`
+	RefID      string `json:"ref_id"`
+	Attempt    string `json:"attempt"`
+	Start      string `json:"start,omitempty"`
+	End        string `json:"end,omitempty"`
+	Duration   string `json:"duration,omitempty"`
+	Result     string `json:"result"`
+	FailedStep string `json:"failed_step,omitempty"`
+	Source     string `json:"source,omitempty"`
+	User       string `json:"user,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+var historyPlan = &cobra.Command{
+	Use:     "history <deployment id> [--kind <kind>] [--ref-id <ref-id>]",
+	Short:   "Shows the plan history of the deployment resources as a timeline",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resKind, _ := cmd.Flags().GetString("kind")
+		refID, _ := cmd.Flags().GetString("ref-id")
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:       true,
+				ShowPlanHistory: true,
+				ShowPlanLogs:    true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		plans, err := planinfo.Get(res, resKind, refID)
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/planhistory", newPlanHistory(plans))
+	},
+}
+
+func init() {
+	Command.AddCommand(historyPlan)
+	cmdutil.AddKindFlag(historyPlan, "Optional", true)
+	historyPlan.Flags().String("ref-id", "", "Optional deployment RefId, only used when --kind is specified")
+}
+
+// newPlanHistory returns the plan history timeline of the resources, followed
+// by any of their pending plans.
+func newPlanHistory(plans []*planinfo.Resource) []planHistoryEntry {
+	var entries = make([]planHistoryEntry, 0)
+	for _, p := range plans {
+		for _, h := range p.History {
+			entries = append(entries, newPlanHistoryEntry(p, h, false))
+		}
+		if p.Pending != nil {
+			entries = append(entries, newPlanHistoryEntry(p, p.Pending, true))
+		}
+	}
+	return entries
+}
+
+func newPlanHistoryEntry(p *planinfo.Resource, a *planinfo.Attempt, pending bool) planHistoryEntry {
+	var entry = planHistoryEntry{
+		Kind:       p.Kind,
+		RefID:      p.RefID,
+		Attempt:    a.Name(),
+		Start:      formatPlanTime(a.AttemptStartTime),
+		End:        formatPlanTime(a.AttemptEndTime),
+		Duration:   a.Duration(),
+		Result:     planResultSuccess,
+		FailedStep: a.FailedStep(),
+	}
+
+	if pending {
+		entry.Result = planResultInProgress
+	} else if a.Healthy != nil && !*a.Healthy {
+		entry.Result = planResultFailed
+	}
+
+	if s := a.Source; s != nil {
+		if s.Action != nil {
+			entry.Source = *s.Action
+		}
+		entry.User = s.UserID
+		if entry.User == "" {
+			entry.User = s.AdminID
+		}
+	}
+
+	if a.Error != nil && a.Error.Message != nil {
+		entry.Message = *a.Error.Message
+	}
+
+	return entry
+}
@@ -0,0 +1,210 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+// getResourcePlan returns the plans of a single deployment resource. When no
+// ref ID is specified, the deployment must have a single resource of the kind.
+func getResourcePlan(res *models.DeploymentGetResponse, kind, refID string) (*planinfo.Resource, error) {
+	plans, err := planinfo.Get(res, kind, refID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(plans) == 0 && refID != "":
+		return nil, fmt.Errorf("deployment has no %s resource with ref id %s", kind, refID)
+	case len(plans) == 0:
+		return nil, fmt.Errorf("deployment has no %s resources", kind)
+	case len(plans) > 1:
+		return nil, fmt.Errorf("deployment has more than one %s resource, please specify --ref-id", kind)
+	}
+
+	return plans[0], nil
+}
+
+// findAttempt returns the resource plan matching the specified attempt, which
+// can be "current", "pending" or the ID or name of a plan attempt in the
+// resource's plan history.
+func findAttempt(p *planinfo.Resource, attempt string) (*planinfo.Attempt, error) {
+	switch attempt {
+	case "current":
+		if p.Current == nil {
+			return nil, fmt.Errorf("%s [%s] has no current plan", p.Kind, p.RefID)
+		}
+		return p.Current, nil
+	case "pending":
+		if p.Pending == nil {
+			return nil, fmt.Errorf("%s [%s] has no pending plan", p.Kind, p.RefID)
+		}
+		return p.Pending, nil
+	}
+
+	for _, h := range p.History {
+		if h.AttemptID == attempt || h.AttemptName == attempt {
+			return h, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s [%s] has no plan attempt %s in its history", p.Kind, p.RefID, attempt)
+}
+
+// indentedPlan returns the attempt's plan as indented JSON.
+func indentedPlan(a *planinfo.Attempt) (string, error) {
+	if len(a.Plan) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, a.Plan, "", "  "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// formatPlanTime formats a plan attempt time, returning an empty string when
+// the time is not set.
+func formatPlanTime(t strfmt.DateTime) string {
+	if time.Time(t).IsZero() {
+		return ""
+	}
+	return t.String()
+}
+
+// lastSuccessfulAttempt returns the most recent successful plan attempt in
+// the resource's plan history which isn't the current plan.
+func lastSuccessfulAttempt(p *planinfo.Resource) (*planinfo.Attempt, error) {
+	for i := len(p.History) - 1; i >= 0; i-- {
+		h := p.History[i]
+		if p.Current != nil && h.AttemptID != "" && h.AttemptID == p.Current.AttemptID {
+			continue
+		}
+		if h.Healthy != nil && *h.Healthy {
+			return h, nil
+		}
+	}
+	return nil, fmt.Errorf("%s [%s] has no previous successful plan in its history", p.Kind, p.RefID)
+}
+
+// withoutTransient returns a copy of the attempt without the plan's transient
+// settings, which only apply to the plan attempt in which they were used.
+func withoutTransient(a *planinfo.Attempt) (*planinfo.Attempt, error) {
+	var attempt = *a
+	if len(a.Plan) == 0 {
+		return &attempt, nil
+	}
+
+	var plan map[string]json.RawMessage
+	if err := json.Unmarshal(a.Plan, &plan); err != nil {
+		return nil, err
+	}
+	delete(plan, "transient")
+
+	b, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+	attempt.Plan = b
+	return &attempt, nil
+}
+
+// newPlanUpdateRequest returns a deployment update request which only contains
+// the specified resource, with its plan replaced by the specified one.
+func newPlanUpdateRequest(res *models.DeploymentGetResponse, kind, refID string, plan json.RawMessage) (*models.DeploymentUpdateRequest, error) {
+	var current = deploymentapi.NewUpdateRequest(res)
+	var req = models.DeploymentUpdateRequest{
+		Name:         current.Name,
+		PruneOrphans: ec.Bool(false),
+		Resources:    &models.DeploymentUpdateResources{},
+	}
+
+	var err error
+	var found bool
+	switch kind {
+	case "elasticsearch":
+		for _, r := range current.Resources.Elasticsearch {
+			if *r.RefID == refID {
+				found, r.Plan = true, new(models.ElasticsearchClusterPlan)
+				err = json.Unmarshal(plan, r.Plan)
+				req.Resources.Elasticsearch = append(req.Resources.Elasticsearch, r)
+			}
+		}
+	case "kibana":
+		for _, r := range current.Resources.Kibana {
+			if *r.RefID == refID {
+				found, r.Plan = true, new(models.KibanaClusterPlan)
+				err = json.Unmarshal(plan, r.Plan)
+				req.Resources.Kibana = append(req.Resources.Kibana, r)
+			}
+		}
+	case "apm":
+		for _, r := range current.Resources.Apm {
+			if *r.RefID == refID {
+				found, r.Plan = true, new(models.ApmPlan)
+				err = json.Unmarshal(plan, r.Plan)
+				req.Resources.Apm = append(req.Resources.Apm, r)
+			}
+		}
+	case "integrations_server":
+		for _, r := range current.Resources.IntegrationsServer {
+			if *r.RefID == refID {
+				found, r.Plan = true, new(models.IntegrationsServerPlan)
+				err = json.Unmarshal(plan, r.Plan)
+				req.Resources.IntegrationsServer = append(req.Resources.IntegrationsServer, r)
+			}
+		}
+	case "appsearch":
+		for _, r := range current.Resources.Appsearch {
+			if *r.RefID == refID {
+				found, r.Plan = true, new(models.AppSearchPlan)
+				err = json.Unmarshal(plan, r.Plan)
+				req.Resources.Appsearch = append(req.Resources.Appsearch, r)
+			}
+		}
+	case "enterprise_search":
+		for _, r := range current.Resources.EnterpriseSearch {
+			if *r.RefID == refID {
+				found, r.Plan = true, new(models.EnterpriseSearchPlan)
+				err = json.Unmarshal(plan, r.Plan)
+				req.Resources.EnterpriseSearch = append(req.Resources.EnterpriseSearch, r)
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding %s [%s] plan: %w", kind, refID, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("unable to build the update request: %s [%s] has no current plan", kind, refID)
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,247 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentplan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+func newKibanaPlan(size int32) *models.KibanaClusterPlan {
+	return &models.KibanaClusterPlan{
+		Kibana: &models.KibanaConfiguration{Version: "8.15.0"},
+		ClusterTopology: []*models.KibanaClusterTopologyElement{{
+			Size: &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(size)},
+		}},
+	}
+}
+
+func newPlansTestDeployment() *models.DeploymentGetResponse {
+	start := strfmt.DateTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	return &models.DeploymentGetResponse{
+		ID:   ec.String("5c17ad7c8df73206baa54b6e2829d9bc"),
+		Name: ec.String("my-deployment"),
+		Resources: &models.DeploymentResources{
+			Kibana: []*models.KibanaResourceInfo{{
+				RefID: ec.String("main-kibana"),
+				Info: &models.KibanaClusterInfo{ClusterName: ec.String("my-deployment"), PlanInfo: &models.KibanaClusterPlansInfo{
+					Current: &models.KibanaClusterPlanInfo{
+						PlanAttemptName: "attempt-0000000001",
+						Healthy:         ec.Bool(true),
+						Plan:            newKibanaPlan(1024),
+					},
+					History: []*models.KibanaClusterPlanInfo{
+						{
+							PlanAttemptName:  "attempt-0000000000",
+							AttemptStartTime: start,
+							AttemptEndTime:   strfmt.DateTime(time.Time(start).Add(90 * time.Second)),
+							Healthy:          ec.Bool(true),
+							Plan: func() *models.KibanaClusterPlan {
+								p := newKibanaPlan(2048)
+								p.Transient = &models.TransientKibanaPlanConfiguration{
+									Strategy: &models.PlanStrategy{Rolling: &models.RollingStrategyConfig{}},
+								}
+								return p
+							}(),
+							Source: &models.ChangeSourceInfo{
+								Action: ec.String("deployments.create-deployment"), UserID: "1234",
+							},
+						},
+						{
+							PlanAttemptName:  "attempt-0000000001",
+							AttemptStartTime: start,
+							Healthy:          ec.Bool(false),
+							Plan:             newKibanaPlan(1024),
+							Error:            &models.ClusterPlanAttemptError{Message: ec.String("timed out")},
+							PlanAttemptLog: []*models.ClusterPlanStepInfo{
+								{StepID: ec.String("plan-started"), Status: ec.String("success")},
+								{StepID: ec.String("wait-until-running"), Status: ec.String("error")},
+								{StepID: ec.String("plan-completed"), Status: ec.String("error")},
+							},
+						},
+					},
+					Pending: &models.KibanaClusterPlanInfo{
+						PlanAttemptName: "attempt-0000000002",
+						Plan:            newKibanaPlan(1024),
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func Test_getResourcePlan(t *testing.T) {
+	res := newPlansTestDeployment()
+
+	_, err := getResourcePlan(res, "apm", "")
+	assert.EqualError(t, err, "deployment has no apm resources")
+
+	_, err = getResourcePlan(res, "kibana", "secondary-kibana")
+	assert.EqualError(t, err, "deployment has no kibana resource with ref id secondary-kibana")
+
+	plans, err := getResourcePlan(res, "kibana", "")
+	require.NoError(t, err)
+	assert.Equal(t, "kibana", plans.Kind)
+	assert.Equal(t, "main-kibana", plans.RefID)
+	assert.Len(t, plans.History, 2)
+
+	_, err = findAttempt(plans, "attempt-0000000005")
+	assert.EqualError(t, err, "kibana [main-kibana] has no plan attempt attempt-0000000005 in its history")
+}
+
+func Test_newPlanHistory(t *testing.T) {
+	plans, err := planinfo.Get(newPlansTestDeployment(), "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []planHistoryEntry{
+		{
+			Kind: "kibana", RefID: "main-kibana", Attempt: "attempt-0000000000",
+			Start: "2024-05-01T10:00:00.000Z", End: "2024-05-01T10:01:30.000Z", Duration: "1m30s",
+			Result: "success", Source: "deployments.create-deployment", User: "1234",
+		},
+		{
+			Kind: "kibana", RefID: "main-kibana", Attempt: "attempt-0000000001",
+			Start: "2024-05-01T10:00:00.000Z", Result: "failed", FailedStep: "wait-until-running",
+			Message: "timed out",
+		},
+		{Kind: "kibana", RefID: "main-kibana", Attempt: "attempt-0000000002", Result: "in-progress"},
+	}, newPlanHistory(plans))
+}
+
+func Test_diffPlanAttempts(t *testing.T) {
+	plans, err := getResourcePlan(newPlansTestDeployment(), "kibana", "")
+	require.NoError(t, err)
+
+	got, err := diffPlanAttempts(plans, "attempt-0000000000", "current")
+	require.NoError(t, err)
+	assert.Equal(t, `--- attempt-0000000000
++++ current
+@@ -3,16 +3,11 @@
+     {
+       "size": {
+         "resource": "memory",
+-        "value": 2048
++        "value": 1024
+       }
+     }
+   ],
+   "kibana": {
+     "version": "8.15.0"
+-  },
+-  "transient": {
+-    "strategy": {
+-      "rolling": {}
+-    }
+   }
+ }
+`, got)
+
+	got, err = diffPlanAttempts(plans, "current", "pending")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = diffPlanAttempts(plans, "attempt-0000000000", "attempt-0000000009")
+	assert.EqualError(t, err, "kibana [main-kibana] has no plan attempt attempt-0000000009 in its history")
+}
+
+func Test_getRollbackAttempt(t *testing.T) {
+	plans, err := getResourcePlan(newPlansTestDeployment(), "kibana", "")
+	require.NoError(t, err)
+
+	got, err := getRollbackAttempt(plans, "")
+	require.NoError(t, err)
+	assert.Equal(t, "attempt-0000000000", got.Name())
+
+	_, err = getRollbackAttempt(plans, "attempt-0000000001")
+	assert.EqualError(t, err, "cannot roll back to attempt-0000000001: the plan attempt wasn't successful")
+
+	_, err = getRollbackAttempt(plans, "attempt-0000000009")
+	assert.EqualError(t, err, "kibana [main-kibana] has no plan attempt attempt-0000000009 in its history")
+
+	plans.History = plans.History[1:]
+	_, err = getRollbackAttempt(plans, "")
+	assert.EqualError(t, err, "kibana [main-kibana] has no previous successful plan in its history")
+}
+
+func Test_newPlanUpdateRequest(t *testing.T) {
+	res := newPlansTestDeployment()
+	plans, err := getResourcePlan(res, "kibana", "")
+	require.NoError(t, err)
+
+	target, err := withoutTransient(plans.History[0])
+	require.NoError(t, err)
+	assert.NotNil(t, plans.History[0].Plan)
+	assert.NotContains(t, string(target.Plan), "transient")
+	assert.Contains(t, string(plans.History[0].Plan), "transient")
+
+	req, err := newPlanUpdateRequest(res, "kibana", "main-kibana", target.Plan)
+	require.NoError(t, err)
+	assert.Equal(t, "my-deployment", req.Name)
+	assert.False(t, *req.PruneOrphans)
+	assert.Empty(t, req.Resources.Elasticsearch)
+	require.Len(t, req.Resources.Kibana, 1)
+	assert.Equal(t, newKibanaPlan(2048), req.Resources.Kibana[0].Plan)
+
+	_, err = newPlanUpdateRequest(res, "apm", "main-apm", target.Plan)
+	assert.EqualError(t, err, "unable to build the update request: apm [main-apm] has no current plan")
+}
+
+func Test_getRetryAttempt(t *testing.T) {
+	plans, err := getResourcePlan(newPlansTestDeployment(), "kibana", "")
+	require.NoError(t, err)
+
+	_, err = getRetryAttempt(plans)
+	assert.EqualError(t, err, "kibana [main-kibana] has a pending plan, wait for it to finish or cancel it first")
+
+	plans.Pending = nil
+	got, err := getRetryAttempt(plans)
+	require.NoError(t, err)
+	assert.Equal(t, "attempt-0000000001", got.Name())
+
+	plans.History = plans.History[:1]
+	_, err = getRetryAttempt(plans)
+	assert.EqualError(t, err, "the last plan attempt of kibana [main-kibana] was successful, there's nothing to retry")
+}
+
+func Test_withPlanConfiguration(t *testing.T) {
+	res := newPlansTestDeployment()
+	plans, err := getResourcePlan(res, "kibana", "")
+	require.NoError(t, err)
+
+	got, err := withPlanConfiguration(plans.History[0], map[string]bool{"override_failsafe": true})
+	require.NoError(t, err)
+
+	req, err := newPlanUpdateRequest(res, "kibana", "main-kibana", got.Plan)
+	require.NoError(t, err)
+	require.Len(t, req.Resources.Kibana, 1)
+
+	want := newKibanaPlan(2048)
+	want.Transient = &models.TransientKibanaPlanConfiguration{
+		Strategy:          &models.PlanStrategy{Rolling: &models.RollingStrategyConfig{}},
+		PlanConfiguration: &models.KibanaPlanControlConfiguration{OverrideFailsafe: ec.Bool(true)},
+	}
+	assert.Equal(t, want, req.Resources.Kibana[0].Plan)
+}
@@ -0,0 +1,212 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentplan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+const retryLong = `Re-submits the last attempted plan of a deployment resource, which is useful when
+the plan failed due to a transient error, such as a snapshot timeout.
+
+The plan's strategy can be overridden for the retry:
+
+  --skip-snapshot       Skips the snapshot before applying the plan (Elasticsearch only).
+  --override-failsafe   Overrides the failsafe checks, which may result in data loss.`
+
+const retryExample = `## Retry the last Elasticsearch plan skipping the snapshot, and track the change.
+$ ecctl deployment plan retry 5c17ad7c8df73206baa54b6e2829d9bc --kind elasticsearch --skip-snapshot --track`
+
+var retryPlan = &cobra.Command{
+	Use:     "retry <deployment id> --kind <kind> [--ref-id <ref-id>]",
+	Short:   "Re-submits the last failed plan of a deployment resource",
+	Long:    retryLong,
+	Example: retryExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resKind, _ := cmd.Flags().GetString("kind")
+		refID, _ := cmd.Flags().GetString("ref-id")
+		skipSnapshot, _ := cmd.Flags().GetBool("skip-snapshot")
+		overrideFailsafe, _ := cmd.Flags().GetBool("override-failsafe")
+
+		if skipSnapshot && resKind != "elasticsearch" {
+			return errors.New("--skip-snapshot can only be used with the elasticsearch kind")
+		}
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:       true,
+				ShowPlanHistory: true,
+				ShowPlanLogs:    true,
+				ShowSettings:    true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		plans, err := getResourcePlan(res, resKind, refID)
+		if err != nil {
+			return err
+		}
+
+		attempt, err := getRetryAttempt(plans)
+		if err != nil {
+			return err
+		}
+
+		var overrides = make(map[string]bool)
+		if skipSnapshot {
+			overrides["skip_snapshot"] = true
+		}
+		if overrideFailsafe {
+			overrides["override_failsafe"] = true
+		}
+
+		attempt, err = withPlanConfiguration(attempt, overrides)
+		if err != nil {
+			return err
+		}
+
+		req, err := newPlanUpdateRequest(res, plans.Kind, plans.RefID, attempt.Plan)
+		if err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		msg := fmt.Sprintf("Plan %s of %s [%s] failed", attempt.Name(), plans.Kind, plans.RefID)
+		if step := attempt.FailedStep(); step != "" {
+			msg += fmt.Sprintf(" on step %s", step)
+		}
+		msg += ". Do you want to retry it? [y/n]: "
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+			DeploymentID: args[0],
+			API:          ecctl.Get().API,
+			Request:      req,
+		})
+		if err != nil {
+			return err
+		}
+
+		track, _ := cmd.Flags().GetBool("track")
+		return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+			App:          ecctl.Get(),
+			DeploymentID: args[0],
+			Track:        track,
+			Response:     updateRes,
+		}))
+	},
+}
+
+func init() {
+	Command.AddCommand(retryPlan)
+	cmdutil.AddKindFlag(retryPlan, "Required", true)
+	retryPlan.MarkFlagRequired("kind")
+	retryPlan.Flags().String("ref-id", "", "Optional deployment RefId, if not set, the RefId will be auto-discovered")
+	retryPlan.Flags().Bool("skip-snapshot", false, "Optional flag to skip the snapshot before applying the plan (Elasticsearch only)")
+	retryPlan.Flags().Bool("override-failsafe", false, "Optional flag to override the failsafe checks of the plan")
+	retryPlan.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+}
+
+// getRetryAttempt returns the last attempted plan of the resource, which must
+// have failed and can't be superseded by a pending plan.
+func getRetryAttempt(plans *planinfo.Resource) (*planinfo.Attempt, error) {
+	if plans.Pending != nil {
+		return nil, fmt.Errorf("%s [%s] has a pending plan, wait for it to finish or cancel it first", plans.Kind, plans.RefID)
+	}
+
+	if len(plans.History) == 0 {
+		return nil, fmt.Errorf("%s [%s] has no plan attempts in its history", plans.Kind, plans.RefID)
+	}
+
+	last := plans.History[len(plans.History)-1]
+	if last.Healthy == nil || *last.Healthy {
+		return nil, fmt.Errorf("the last plan attempt of %s [%s] was successful, there's nothing to retry", plans.Kind, plans.RefID)
+	}
+
+	return last, nil
+}
+
+// withPlanConfiguration returns a copy of the attempt with the specified
+// settings set in the plan's transient plan configuration.
+func withPlanConfiguration(a *planinfo.Attempt, settings map[string]bool) (*planinfo.Attempt, error) {
+	var attempt = *a
+	if len(settings) == 0 {
+		return &attempt, nil
+	}
+
+	var plan map[string]json.RawMessage
+	if err := json.Unmarshal(a.Plan, &plan); err != nil {
+		return nil, err
+	}
+
+	var transient map[string]json.RawMessage
+	if t, ok := plan["transient"]; ok {
+		if err := json.Unmarshal(t, &transient); err != nil {
+			return nil, err
+		}
+	}
+	if transient == nil {
+		transient = make(map[string]json.RawMessage)
+	}
+
+	var config map[string]interface{}
+	if c, ok := transient["plan_configuration"]; ok {
+		if err := json.Unmarshal(c, &config); err != nil {
+			return nil, err
+		}
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+
+	for k, v := range settings {
+		config[k] = v
+	}
+
+	var err error
+	if transient["plan_configuration"], err = json.Marshal(config); err != nil {
+		return nil, err
+	}
+	if plan["transient"], err = json.Marshal(transient); err != nil {
+		return nil, err
+	}
+	if attempt.Plan, err = json.Marshal(plan); err != nil {
+		return nil, err
+	}
+
+	return &attempt, nil
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentplan
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/planinfo"
+)
+
+const rollbackLong = `Rolls back a deployment resource to a previous successful plan. By default, the
+most recent successful plan in the resource's plan history which isn't the current
+plan is used, a specific plan attempt can be chosen with --to.
+
+The transient settings of the plan are cleared, and the changes between the current
+plan and the plan to roll back to are shown before asking for confirmation.`
+
+const rollbackExample = `## Roll back Elasticsearch to its previous successful plan and track the change.
+$ ecctl deployment plan rollback 5c17ad7c8df73206baa54b6e2829d9bc --kind elasticsearch --track
+
+## Roll back Kibana to a specific plan attempt.
+$ ecctl deployment plan rollback 5c17ad7c8df73206baa54b6e2829d9bc --kind kibana --to attempt-0000000003`
+
+var rollbackPlan = &cobra.Command{
+	Use:     "rollback <deployment id> --kind <kind> [--ref-id <ref-id>] [--to <attempt>]",
+	Short:   "Rolls back a deployment resource to a previous successful plan",
+	Long:    rollbackLong,
+	Example: rollbackExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resKind, _ := cmd.Flags().GetString("kind")
+		refID, _ := cmd.Flags().GetString("ref-id")
+		to, _ := cmd.Flags().GetString("to")
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:       true,
+				ShowPlanHistory: true,
+				ShowSettings:    true,
+				ClearTransient:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		plans, err := getResourcePlan(res, resKind, refID)
+		if err != nil {
+			return err
+		}
+
+		target, err := getRollbackAttempt(plans, to)
+		if err != nil {
+			return err
+		}
+
+		target, err = withoutTransient(target)
+		if err != nil {
+			return err
+		}
+
+		req, err := newPlanUpdateRequest(res, plans.Kind, plans.RefID, target.Plan)
+		if err != nil {
+			return err
+		}
+
+		if plans.Current != nil {
+			diff, err := diffPlans(plans.Current, target, "current", target.Name())
+			if err != nil {
+				return err
+			}
+			if diff == "" {
+				diff = fmt.Sprintf("No changes between the current plan and %s\n", target.Name())
+			}
+			_, _ = fmt.Fprint(ecctl.Get().Config.OutputDevice, diff)
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		msg := fmt.Sprintf("Do you want to roll back %s [%s] to %s? [y/n]: ",
+			plans.Kind, plans.RefID, target.Name(),
+		)
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+			DeploymentID: args[0],
+			API:          ecctl.Get().API,
+			Request:      req,
+		})
+		if err != nil {
+			return err
+		}
+
+		track, _ := cmd.Flags().GetBool("track")
+		return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+			App:          ecctl.Get(),
+			DeploymentID: args[0],
+			Track:        track,
+			Response:     updateRes,
+		}))
+	},
+}
+
+func init() {
+	Command.AddCommand(rollbackPlan)
+	cmdutil.AddKindFlag(rollbackPlan, "Required", true)
+	rollbackPlan.MarkFlagRequired("kind")
+	rollbackPlan.Flags().String("ref-id", "", "Optional deployment RefId, if not set, the RefId will be auto-discovered")
+	rollbackPlan.Flags().String("to", "", "Optional plan attempt ID or name to roll back to, defaults to the last successful plan")
+	rollbackPlan.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+}
+
+// getRollbackAttempt returns the plan attempt to roll back to, which must have
+// been successful.
+func getRollbackAttempt(plans *planinfo.Resource, to string) (*planinfo.Attempt, error) {
+	if to == "" {
+		return lastSuccessfulAttempt(plans)
+	}
+
+	for _, h := range plans.History {
+		if h.AttemptID != to && h.AttemptName != to {
+			continue
+		}
+		if h.Healthy == nil || !*h.Healthy {
+			return nil, fmt.Errorf("cannot roll back to %s: the plan attempt wasn't successful", to)
+		}
+		return h, nil
+	}
+
+	return nil, fmt.Errorf("%s [%s] has no plan attempt %s in its history", plans.Kind, plans.RefID, to)
+}
@@ -0,0 +1,206 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const scaleLong = `Changes the size, zone count or autoscaling maximum size of an Elasticsearch tier,
+or the size and zone count of Kibana, Integrations Server and Enterprise Search, without the need of a full update payload.
+
+The values are validated against the limits of the deployment template the deployment
+is based on, and only the changed resources are sent in the update request.`
+
+const scaleExample = `## Scale the hot tier to 8g per zone across 3 zones and wait for the change to finish.
+$ ecctl deployment scale 5c17ad7c8df73206baa54b6e2829d9bc --tier hot_content --size 8g --zones 3 --track
+
+## Enable the warm tier and allow the hot tier to autoscale up to 64g per zone.
+$ ecctl deployment scale 5c17ad7c8df73206baa54b6e2829d9bc --tier warm --size 4g
+$ ecctl deployment scale 5c17ad7c8df73206baa54b6e2829d9bc --autoscale-max 64g
+
+## Scale Kibana to 2g per zone.
+$ ecctl deployment scale 5c17ad7c8df73206baa54b6e2829d9bc --kibana-size 2g`
+
+const noDeploymentTemplateWarning = "warning: the deployment is not based on a deployment template, sizes are only partially validated"
+
+var errNoTopologyFlags = errors.New("at least one of the topology flags must be specified")
+
+var scaleCmd = &cobra.Command{
+	Use:     "scale <deployment id> [--tier <id>] [--size <size>] [--zones <count>] [--autoscale-max <size>] [--kibana-size <size>] [--kibana-zones <count>]",
+	Short:   "Changes the topology size of a deployment's Elasticsearch tiers and stateless resources",
+	Long:    scaleLong,
+	Example: scaleExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags, err := parseTopologyFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if !flags.changed() {
+			return errNoTopologyFlags
+		}
+
+		res, err := getDeploymentForUpdate(args[0])
+		if err != nil {
+			return err
+		}
+
+		tpl, err := getDeploymentTemplate(res)
+		if err != nil {
+			return err
+		}
+		if tpl == nil {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), noDeploymentTemplateWarning)
+		}
+
+		req := newScaleRequest(res, flags)
+		if err := applyTopologyFlags(flags, newTopologyConstraints(tpl), topologyResources{
+			elasticsearch:      req.Resources.Elasticsearch,
+			kibana:             req.Resources.Kibana,
+			integrationsServer: req.Resources.IntegrationsServer,
+			enterpriseSearch:   req.Resources.EnterpriseSearch,
+		}); err != nil {
+			return err
+		}
+
+		return updateTopology(cmd, args[0], req)
+	},
+}
+
+func init() {
+	initScaleFlags()
+}
+
+func initScaleFlags() {
+	Command.AddCommand(scaleCmd)
+	scaleCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	addTopologyFlags(scaleCmd)
+}
+
+// newScaleRequest builds a minimal update request from the deployment, which
+// only contains the resources affected by the topology flags.
+func newScaleRequest(res *models.DeploymentGetResponse, flags topologyFlags) *models.DeploymentUpdateRequest {
+	req := deploymentapi.NewUpdateRequest(res)
+	var resources models.DeploymentUpdateResources
+	if flags.elasticsearchChanged() {
+		resources.Elasticsearch = req.Resources.Elasticsearch
+	}
+	if flags.kibanaChanged() {
+		resources.Kibana = req.Resources.Kibana
+	}
+	if flags.integrationsServerChanged() {
+		resources.IntegrationsServer = req.Resources.IntegrationsServer
+	}
+	if flags.enterpriseSearchChanged() {
+		resources.EnterpriseSearch = req.Resources.EnterpriseSearch
+	}
+
+	req.Resources = &resources
+	req.Settings = nil
+	return req
+}
+
+// getDeploymentForUpdate obtains the deployment with the plans and settings
+// which are needed to build an update request.
+func getDeploymentForUpdate(id string) (*models.DeploymentGetResponse, error) {
+	return deploymentapi.Get(deploymentapi.GetParams{
+		API:          ecctl.Get().API,
+		DeploymentID: id,
+		QueryParams: deputil.QueryParams{
+			ShowPlans:      true,
+			ShowSettings:   true,
+			ClearTransient: true,
+		},
+	})
+}
+
+// updateTopology sends the update request and tracks the changes when the
+// --track flag is set.
+func updateTopology(cmd *cobra.Command, id string, req *models.DeploymentUpdateRequest) error {
+	var region = ecctl.Get().Config.Region
+	if region == "" {
+		region = cmdutil.DefaultECERegion
+	}
+
+	res, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		DeploymentID: id,
+		API:          ecctl.Get().API,
+		Overrides:    deploymentapi.PayloadOverrides{Region: region},
+		Request:      req,
+	})
+	if err != nil {
+		return err
+	}
+
+	track, _ := cmd.Flags().GetBool("track")
+	return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+		App:          ecctl.Get(),
+		DeploymentID: id,
+		Track:        track,
+		Response:     res,
+	}))
+}
+
+// getDeploymentTemplate obtains the deployment template referenced by the
+// deployment's Elasticsearch resource, for the current Elasticsearch version.
+// When the deployment is not based on a deployment template, nil is returned.
+func getDeploymentTemplate(res *models.DeploymentGetResponse) (*models.DeploymentTemplateInfoV2, error) {
+	if res.Resources == nil || len(res.Resources.Elasticsearch) == 0 {
+		return nil, nil
+	}
+
+	es := res.Resources.Elasticsearch[0]
+	if es.Info == nil || es.Info.PlanInfo == nil || es.Info.PlanInfo.Current == nil {
+		return nil, nil
+	}
+
+	plan := es.Info.PlanInfo.Current.Plan
+	if plan == nil || plan.DeploymentTemplate == nil || plan.DeploymentTemplate.ID == nil {
+		return nil, nil
+	}
+
+	var version string
+	if plan.Elasticsearch != nil {
+		version = plan.Elasticsearch.Version
+	}
+
+	var region = ecctl.Get().Config.Region
+	if es.Region != nil && *es.Region != "" {
+		region = *es.Region
+	}
+
+	return deptemplateapi.Get(deptemplateapi.GetParams{
+		API:          ecctl.Get().API,
+		TemplateID:   *plan.DeploymentTemplate.ID,
+		Region:       region,
+		StackVersion: version,
+		ShowMaxZones: true,
+	})
+}
@@ -18,15 +18,38 @@
 package cmddeployment
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
-	"github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
-	"github.com/elastic/ecctl/pkg/ecctl"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
 )
 
-const searchQueryLong = `Read more about Query DSL in https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl.html`
+const searchQueryLong = `Performs a deployment search using one of the following forms:
+
+  * A JSON file containing the search request, with --file (shorthand: -f). Use "-f -" to read it from stdin.
+  * An inline JSON search request with --query.
+  * A query string with --q, such as 'name:prod* AND healthy:false'.
+  * A saved search with --saved, which refers to an entry of the "saved_searches" setting in the ecctl
+    config file. Each saved search is either a JSON search request or a query string:
+
+      saved_searches:
+        unhealthy: "healthy:false"
+
+When --output ndjson is used, each deployment is written on its own line as soon as its page of results
+is received and the progress is reported on stderr, so that large searches don't need to be kept in memory.
+The number of returned deployments can be limited with --max-results.
+
+Read more about Query DSL in https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl.html`
 
 var searchExamples = `
 $ cat query_string_query.json
@@ -38,16 +61,31 @@ $ cat query_string_query.json
     }
 }
 $ ecctl deployment search -f query_string_query.json
-[...]`[1:]
+[...]
+
+## Search using an inline query, a query string or a query read from stdin.
+$ ecctl deployment search --query '{"query": {"match": {"name": {"query": "admin"}}}}'
+$ ecctl deployment search --q 'name:prod* AND healthy:false' --all-matches
+$ cat query_string_query.json | ecctl deployment search -f -
+
+## Run the "unhealthy" saved search.
+$ ecctl deployment search --saved unhealthy
+
+## Stream all the matches as newline delimited JSON, stopping after 20000 deployments.
+$ ecctl deployment search --q 'healthy:false' --all-matches --max-results 20000 --output ndjson > unhealthy.ndjson`[1:]
+
+// searchQueryFlags are the flags which specify the deployment search query,
+// exactly one of which must be used.
+var searchQueryFlags = []string{"file", "query", "q", "saved"}
 
 var searchCmd = &cobra.Command{
-	Use:     `search -f <query file.json>`,
+	Use:     `search {-f <query file.json> | --query <json> | --q <query string> | --saved <name>}`,
 	Short:   "Performs advanced deployment search using the Elasticsearch Query DSL",
 	Long:    searchQueryLong,
 	Example: searchExamples,
 	PreRunE: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		sr, err := cmdutil.ParseQueryDSLFile(cmd.Flag("file").Value.String())
+		sr, err := newSearchRequest(cmd)
 		if err != nil {
 			return err
 		}
@@ -56,17 +94,22 @@ var searchCmd = &cobra.Command{
 		if returnAllMatches && sr.Sort == nil {
 			return fmt.Errorf("the query must include a sort-field when using --all-matches. Example: \"sort\": [\"id\"]")
 		}
-
 		batchSize, _ := cmd.Flags().GetInt32("size")
+		maxResults, _ := cmd.Flags().GetInt32("max-results")
+		stream := ecctl.Get().Config.Output == ecctl.NDJSONOutput
 
 		var result *models.DeploymentsSearchResponse
 		var cursor string
-		for i := 0; i < 100; i++ {
+		var returned int32
+		for {
 			sr.Cursor = cursor
 			if returnAllMatches {
 				// Custom batch-size to override any size already set in the input query
 				sr.Size = batchSize
 			}
+			if remaining := maxResults - returned; maxResults > 0 && (sr.Size == 0 || sr.Size > remaining) {
+				sr.Size = remaining
+			}
 
 			res, err := deploymentapi.Search(deploymentapi.SearchParams{
 				API:     ecctl.Get().API,
@@ -78,8 +121,22 @@ var searchCmd = &cobra.Command{
 			}
 
 			cursor = res.Cursor
+			if remaining := maxResults - returned; maxResults > 0 && int32(len(res.Deployments)) > remaining {
+				res.Deployments = res.Deployments[:remaining]
+				res.ReturnCount = ec.Int32(remaining)
+			}
+			returned += int32(len(res.Deployments))
 
-			if result == nil {
+			if stream {
+				// Write the deployments as soon as each page arrives, instead
+				// of keeping all of them in memory.
+				for _, d := range res.Deployments {
+					if err := ecctl.Get().Formatter.Format("", d); err != nil {
+						return err
+					}
+				}
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Fetched %d of %d matching deployments\n", returned, res.MatchCount)
+			} else if result == nil {
 				result = res
 				result.Cursor = "" // Hide cursor in output
 			} else {
@@ -89,20 +146,105 @@ var searchCmd = &cobra.Command{
 				result.MatchCount = newReturnCount
 			}
 
-			if *res.ReturnCount == 0 || !returnAllMatches {
+			if len(res.Deployments) == 0 || !returnAllMatches || cursor == "" ||
+				(maxResults > 0 && returned >= maxResults) {
 				break
 			}
 		}
 
+		if stream {
+			return nil
+		}
 		return ecctl.Get().Formatter.Format("deployment/search", result)
 	},
 }
 
 func init() {
 	Command.AddCommand(searchCmd)
-	searchCmd.Flags().StringP("file", "f", "", "JSON file that contains JSON-style domain-specific language query")
-	searchCmd.MarkFlagRequired("file")
+	initSearchFlags()
+}
+
+func initSearchFlags() {
+	searchCmd.Flags().StringP("file", "f", "", `JSON file that contains JSON-style domain-specific language query, use "-" to read it from stdin`)
+	searchCmd.Flags().String("query", "", "Searches using a given JSON query")
+	searchCmd.Flags().String("q", "", "Searches using a query string, such as 'name:prod* AND healthy:false'")
+	searchCmd.Flags().String("saved", "", "Searches using a saved search from the ecctl config")
 	searchCmd.Flags().BoolP("all-matches", "a", false,
 		"Uses a cursor to return all matches of the query (ignoring the size in the query). This can be used to query more than 10k results.")
 	searchCmd.Flags().Int32("size", 500, "Defines the size per request when using the --all-matches option.")
+	searchCmd.Flags().Int32("max-results", 0, "Maximum number of deployments to return, 0 returns all the matches")
+}
+
+// newSearchRequest builds the search request from whichever of the query flags
+// is set. Query strings are sorted by ID so they can be used with --all-matches.
+func newSearchRequest(cmd *cobra.Command) (models.SearchRequest, error) {
+	var sr models.SearchRequest
+	if err := cmdutil.ExactlyOneFlag(cmd, searchQueryFlags...); err != nil {
+		return sr, err
+	}
+
+	file, _ := cmd.Flags().GetString("file")
+	query, _ := cmd.Flags().GetString("query")
+	queryString, _ := cmd.Flags().GetString("q")
+	if saved, _ := cmd.Flags().GetString("saved"); saved != "" {
+		search, err := getSavedSearch(saved)
+		if err != nil {
+			return sr, err
+		}
+		if strings.HasPrefix(strings.TrimSpace(search), "{") {
+			query = search
+		} else {
+			queryString = search
+		}
+	}
+
+	switch {
+	case file == "-":
+		if err := json.NewDecoder(cmd.InOrStdin()).Decode(&sr); err != nil {
+			return sr, fmt.Errorf("failed decoding the query from stdin: %w", err)
+		}
+		return sr, nil
+	case file != "":
+		return sdkcmdutil.ParseQueryDSLFile(file)
+	case query != "":
+		err := json.Unmarshal([]byte(query), &sr)
+		if err != nil {
+			if uq, uerr := strconv.Unquote(query); uerr == nil {
+				err = json.Unmarshal([]byte(uq), &sr)
+			}
+		}
+		if err != nil {
+			return sr, fmt.Errorf("failed decoding the query: %w", err)
+		}
+		return sr, nil
+	}
+
+	return models.SearchRequest{
+		Query: &models.QueryContainer{
+			QueryString: &models.QueryStringQuery{Query: ec.String(queryString)},
+		},
+		Sort: []interface{}{"id"},
+	}, nil
+}
+
+// getSavedSearch returns the saved search with the specified name from the
+// ecctl config. Names aren't case sensitive since the config keys aren't.
+func getSavedSearch(name string) (string, error) {
+	var searches = ecctl.Get().Config.SavedSearches
+	for k, v := range searches {
+		if strings.EqualFold(k, name) {
+			return v, nil
+		}
+	}
+
+	if len(searches) == 0 {
+		return "", fmt.Errorf(`saved search "%s" not found, no saved searches are defined in the ecctl config`, name)
+	}
+
+	var names = make([]string, 0, len(searches))
+	for k := range searches {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf(`saved search "%s" not found, available saved searches: %s`, name, strings.Join(names, ", "))
 }
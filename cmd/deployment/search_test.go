@@ -18,14 +18,36 @@
 package cmddeployment
 
 import (
+	"strings"
+	"testing"
+
 	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+
 	"github.com/elastic/ecctl/cmd/util/testutils"
-	"testing"
 )
 
+// newSearchAssertion returns a search response with a single deployment,
+// asserting that the request matches the specified body.
+func newSearchAssertion(body string) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Method: "POST",
+			Path:   "/api/v1/deployments/_search",
+			Host:   api.DefaultMockHost,
+			Body:   mock.NewStringBody(body + "\n"),
+		},
+		mock.NewStructBody(models.DeploymentsSearchResponse{
+			Deployments: []*models.DeploymentSearchResponse{{ID: ec.String("d1")}},
+			MatchCount:  1,
+			ReturnCount: ec.Int32(1),
+		}),
+	)
+}
+
 func Test_searchCmd(t *testing.T) {
 	tests := []struct {
 		name string
@@ -146,14 +168,153 @@ func Test_searchCmd(t *testing.T) {
 				Err: "the query must include a sort-field when using --all-matches. Example: \"sort\": [\"id\"]",
 			},
 		},
+		{
+			name: "fails when more than one query flag is used",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "-f", "testdata/search_query.json", "--q", "healthy:false"},
+			},
+			want: testutils.Assertion{
+				Err: `conflicting flags: "--file", "--q" should not be used together`,
+			},
+		},
+		{
+			name: "searches using an inline query",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "--query", `{"query":{"match_all":{}},"size":5}`},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newSearchAssertion(`{"query":{"match_all":{}},"size":5,"sort":null}`),
+				}},
+			},
+			want: testutils.Assertion{Stdout: searchSingleOutput},
+		},
+		{
+			name: "searches using a query string",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "--q", "name:prod* AND healthy:false"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newSearchAssertion(`{"query":{"query_string":{"query":"name:prod* AND healthy:false"}},"sort":["id"]}`),
+				}},
+			},
+			want: testutils.Assertion{Stdout: searchSingleOutput},
+		},
+		{
+			name: "searches using a query read from stdin",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "-f", "-"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newSearchAssertion(`{"query":{"match_all":{}},"sort":["id"]}`),
+				}},
+			},
+			want: testutils.Assertion{Stdout: searchSingleOutput},
+		},
+		{
+			name: "searches using a saved search",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "--saved", "Unhealthy"},
+				Cfg: testutils.MockCfg{
+					SavedSearches: map[string]string{"unhealthy": "healthy:false"},
+					Responses: []mock.Response{
+						newSearchAssertion(`{"query":{"query_string":{"query":"healthy:false"}},"sort":["id"]}`),
+					},
+				},
+			},
+			want: testutils.Assertion{Stdout: searchSingleOutput},
+		},
+		{
+			name: "fails when the saved search doesn't exist",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "--saved", "healthy"},
+				Cfg: testutils.MockCfg{SavedSearches: map[string]string{
+					"unhealthy": "healthy:false",
+					"all":       `{"query":{"match_all":{}}}`,
+				}},
+			},
+			want: testutils.Assertion{
+				Err: `saved search "healthy" not found, available saved searches: all, unhealthy`,
+			},
+		},
+		{
+			name: "streams the matches as ndjson up to the maximum number of results",
+			args: testutils.Args{
+				Cmd:  searchCmd,
+				Args: []string{"search", "--q", "healthy:false", "--all-matches", "--size", "2", "--max-results", "3"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "ndjson",
+					Responses: []mock.Response{
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "POST",
+								Path:   "/api/v1/deployments/_search",
+								Host:   api.DefaultMockHost,
+								Body:   mock.NewStringBody(`{"query":{"query_string":{"query":"healthy:false"}},"size":2,"sort":["id"]}` + "\n"),
+							},
+							mock.NewStructBody(models.DeploymentsSearchResponse{
+								Cursor:      "cursor1",
+								Deployments: []*models.DeploymentSearchResponse{{ID: ec.String("d1")}, {ID: ec.String("d2")}},
+								MatchCount:  4,
+								ReturnCount: ec.Int32(2),
+							}),
+						),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "POST",
+								Path:   "/api/v1/deployments/_search",
+								Host:   api.DefaultMockHost,
+								Body: mock.NewStringBody(`{"cursor":"cursor1","query":{"query_string":{"query":"healthy:false"}},` +
+									`"size":1,"sort":["id"]}` + "\n"),
+							},
+							mock.NewStructBody(models.DeploymentsSearchResponse{
+								Cursor:      "cursor2",
+								Deployments: []*models.DeploymentSearchResponse{{ID: ec.String("d3")}},
+								MatchCount:  4,
+								ReturnCount: ec.Int32(1),
+							}),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: `{"healthy":null,"id":"d1","name":null,"resources":null}` + "\n" +
+					`{"healthy":null,"id":"d2","name":null,"resources":null}` + "\n" +
+					`{"healthy":null,"id":"d3","name":null,"resources":null}` + "\n",
+				Stderr: "Fetched 2 of 4 matching deployments\nFetched 3 of 4 matching deployments\n",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			searchCmd.SetIn(strings.NewReader(`{"query":{"match_all":{}},"sort":["id"]}`))
+			defer searchCmd.SetIn(nil)
 			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initSearchFlags()
 		})
 	}
 }
 
+var searchSingleOutput = `{
+  "deployments": [
+    {
+      "healthy": null,
+      "id": "d1",
+      "name": null,
+      "resources": null
+    }
+  ],
+  "match_count": 1,
+  "minimal_metadata": null,
+  "return_count": 1
+}
+`
+
 var expectedOutput = `{
   "deployments": [
     {
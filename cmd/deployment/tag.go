@@ -0,0 +1,261 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const tagExample = `## List the tags of a deployment.
+$ ecctl deployment tag list 5c17ad7c8df73206baa54b6e2829d9bc
+
+## Add or replace the team and cost-center tags of a deployment.
+$ ecctl deployment tag add 5c17ad7c8df73206baa54b6e2829d9bc team=search cost-center=1234
+
+## Remove the cost-center tag from all the deployments matching a query.
+$ ecctl deployment tag remove --all-matching query.json cost-center`
+
+// tagChangeFunc returns the result of applying the specified tags to the
+// current deployment tags.
+type tagChangeFunc func(current, tags []*models.MetadataItem) []*models.MetadataItem
+
+var tagCmd = &cobra.Command{
+	Use:     "tag",
+	Short:   "Manages deployment tags",
+	Example: tagExample,
+	PreRunE: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var tagListCmd = &cobra.Command{
+	Use:     "list <deployment id>",
+	Short:   "Lists the tags of a deployment",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, err := getDeploymentTags(args[0])
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/taglist", tags)
+	},
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:     "add {<deployment id> | --all-matching <query file>} <key=value>...",
+	Short:   "Adds tags to a deployment, replacing the value of any existing keys",
+	PreRunE: checkTagArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTagChange(cmd, args, true, "add tags to", addTags)
+	},
+}
+
+var tagRemoveCmd = &cobra.Command{
+	Use:     "remove {<deployment id> | --all-matching <query file>} <key[=value]>...",
+	Short:   "Removes tags from a deployment, by key or by key and value",
+	PreRunE: checkTagArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTagChange(cmd, args, false, "remove tags from", removeTags)
+	},
+}
+
+func init() {
+	Command.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagListCmd, tagAddCmd, tagRemoveCmd)
+	initTagAddFlags()
+	initTagRemoveFlags()
+}
+
+func initTagAddFlags() {
+	tagAddCmd.Flags().String("all-matching", "", "JSON file that contains a Query DSL query, the tags are added to all the matching deployments")
+}
+
+func initTagRemoveFlags() {
+	tagRemoveCmd.Flags().String("all-matching", "", "JSON file that contains a Query DSL query, the tags are removed from all the matching deployments")
+}
+
+// checkTagArgs ensures that a deployment ID and at least one tag are specified,
+// or only tags when --all-matching is used.
+func checkTagArgs(cmd *cobra.Command, args []string) error {
+	if query, _ := cmd.Flags().GetString("all-matching"); query != "" {
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}
+	return sdkcmdutil.MinimumNArgsAndUUID(2)(cmd, args)
+}
+
+// runTagChange applies the tag change to either the deployment specified in
+// the first argument or all the deployments matching the --all-matching query.
+func runTagChange(cmd *cobra.Command, args []string, requireValue bool, description string, change tagChangeFunc) error {
+	queryFile, _ := cmd.Flags().GetString("all-matching")
+	if queryFile == "" {
+		tags, err := parseTags(args[1:], requireValue)
+		if err != nil {
+			return err
+		}
+
+		current, err := getDeploymentTags(args[0])
+		if err != nil {
+			return err
+		}
+
+		newTags := change(current, tags)
+		if err := updateDeploymentTags(args[0], newTags); err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("deployment/taglist", newTags)
+	}
+
+	tags, err := parseTags(args, requireValue)
+	if err != nil {
+		return err
+	}
+
+	sr, err := sdkcmdutil.ParseQueryDSLFile(queryFile)
+	if err != nil {
+		return err
+	}
+	if sr.Sort == nil {
+		sr.Sort = []interface{}{"id"}
+	}
+
+	res, err := searchAllDeployments(&sr)
+	if err != nil {
+		return err
+	}
+
+	var targets = make([]bulkTarget, 0, len(res.Deployments))
+	var current = make(map[string][]*models.MetadataItem, len(res.Deployments))
+	for _, d := range res.Deployments {
+		targets = append(targets, bulkTarget{ID: *d.ID, Name: *d.Name})
+		if d.Metadata != nil {
+			current[*d.ID] = d.Metadata.Tags
+		}
+	}
+
+	return runBulkTargets(cmd, targets, defaultBulkConcurrency, description, os.Stdin, func(id string) error {
+		return updateDeploymentTags(id, change(current[id], tags))
+	})
+}
+
+// parseTags parses key=value arguments into metadata items. When the value is
+// not required, a key without a value results in a nil value.
+func parseTags(args []string, requireValue bool) ([]*models.MetadataItem, error) {
+	var merr = multierror.NewPrefixed("invalid tags")
+	var tags = make([]*models.MetadataItem, 0, len(args))
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if kv[0] == "" || (requireValue && len(kv) != 2) {
+			merr = merr.Append(fmt.Errorf(`"%s" must be in the key=value format`, arg))
+			continue
+		}
+
+		var tag = models.MetadataItem{Key: ec.String(kv[0])}
+		if len(kv) == 2 {
+			tag.Value = ec.String(kv[1])
+		}
+		tags = append(tags, &tag)
+	}
+
+	return tags, merr.ErrorOrNil()
+}
+
+// addTags returns the current tags with the new tags added. The values of any
+// existing keys are replaced.
+func addTags(current, tags []*models.MetadataItem) []*models.MetadataItem {
+	var result = make([]*models.MetadataItem, 0, len(current)+len(tags))
+	var index = make(map[string]int)
+	for _, list := range [][]*models.MetadataItem{current, tags} {
+		for _, t := range list {
+			if i, ok := index[*t.Key]; ok {
+				result[i] = t
+				continue
+			}
+			index[*t.Key] = len(result)
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// removeTags returns the current tags without the specified tags. Tags without
+// a value remove the key regardless of its value.
+func removeTags(current, tags []*models.MetadataItem) []*models.MetadataItem {
+	var result = make([]*models.MetadataItem, 0, len(current))
+	for _, c := range current {
+		var remove bool
+		for _, t := range tags {
+			if *t.Key == *c.Key && (t.Value == nil || *t.Value == *c.Value) {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func getDeploymentTags(id string) ([]*models.MetadataItem, error) {
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API:          ecctl.Get().API,
+		DeploymentID: id,
+		QueryParams:  deputil.QueryParams{ShowMetadata: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Metadata == nil {
+		return []*models.MetadataItem{}, nil
+	}
+	return res.Metadata.Tags, nil
+}
+
+// updateDeploymentTags replaces the deployment tags with a minimal update which
+// doesn't modify any of the deployment resources.
+func updateDeploymentTags(id string, tags []*models.MetadataItem) error {
+	if tags == nil {
+		tags = []*models.MetadataItem{}
+	}
+
+	_, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		API:          ecctl.Get().API,
+		DeploymentID: id,
+		Request: &models.DeploymentUpdateRequest{
+			PruneOrphans: ec.Bool(false),
+			Metadata:     &models.DeploymentUpdateMetadata{Tags: tags},
+		},
+	})
+	return err
+}
@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func newTag(key, value string) *models.MetadataItem {
+	return &models.MetadataItem{Key: ec.String(key), Value: ec.String(value)}
+}
+
+func Test_parseTags(t *testing.T) {
+	got, err := parseTags([]string{"team=search", "empty=", "key"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.MetadataItem{
+		newTag("team", "search"),
+		newTag("empty", ""),
+		{Key: ec.String("key")},
+	}, got)
+
+	_, err = parseTags([]string{"team=search", "key", "=value"}, true)
+	assert.EqualError(t, err, "invalid tags: 2 errors occurred:\n"+
+		"\t* \"=value\" must be in the key=value format\n"+
+		"\t* \"key\" must be in the key=value format\n\n",
+	)
+}
+
+func Test_addTags(t *testing.T) {
+	got := addTags(
+		[]*models.MetadataItem{newTag("team", "search"), newTag("env", "prod")},
+		[]*models.MetadataItem{newTag("team", "observability"), newTag("cost-center", "1234")},
+	)
+	assert.Equal(t, []*models.MetadataItem{
+		newTag("team", "observability"),
+		newTag("env", "prod"),
+		newTag("cost-center", "1234"),
+	}, got)
+}
+
+func Test_removeTags(t *testing.T) {
+	got := removeTags(
+		[]*models.MetadataItem{newTag("team", "search"), newTag("env", "prod"), newTag("cost-center", "1234")},
+		[]*models.MetadataItem{{Key: ec.String("team")}, newTag("env", "staging"), newTag("cost-center", "1234")},
+	)
+	assert.Equal(t, []*models.MetadataItem{newTag("env", "prod")}, got)
+}
+
+func Test_tagAddCmd(t *testing.T) {
+	const id = "5c17ad7c8df73206baa54b6e2829d9bc"
+	getResponse := mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Method: "GET",
+			Path:   "/api/v1/deployments/" + id,
+			Host:   api.DefaultMockHost,
+			Query: url.Values{
+				"convert_legacy_plans": {"false"},
+				"show_metadata":        {"true"},
+				"show_plan_defaults":   {"false"},
+				"show_plan_history":    {"false"},
+				"show_plan_logs":       {"false"},
+				"show_plans":           {"false"},
+				"show_settings":        {"false"},
+				"show_system_alerts":   {"5"},
+			},
+		},
+		mock.NewStructBody(models.DeploymentGetResponse{
+			ID: ec.String(id),
+			Metadata: &models.DeploymentMetadata{
+				Tags: []*models.MetadataItem{newTag("team", "search")},
+			},
+		}),
+	)
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "fails when no tags are specified",
+			args: testutils.Args{
+				Cmd:  tagAddCmd,
+				Args: []string{"tag", "add", id},
+			},
+			want: testutils.Assertion{Err: "requires at least 2 arg(s), only received 1"},
+		},
+		{
+			name: "fails on an invalid tag",
+			args: testutils.Args{
+				Cmd:  tagAddCmd,
+				Args: []string{"tag", "add", id, "team"},
+			},
+			want: testutils.Assertion{Err: "invalid tags: 1 error occurred:\n" +
+				"\t* \"team\" must be in the key=value format\n\n",
+			},
+		},
+		{
+			name: "adds the tags with a minimal update",
+			args: testutils.Args{
+				Cmd:  tagAddCmd,
+				Args: []string{"tag", "add", id, "env=prod"},
+				Cfg: testutils.MockCfg{OutputFormat: "text", Responses: []mock.Response{
+					getResponse,
+					mock.New200ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Method: "PUT",
+							Path:   "/api/v1/deployments/" + id,
+							Host:   api.DefaultMockHost,
+							Query: url.Values{
+								"hide_pruned_orphans": {"false"},
+								"skip_snapshot":       {"false"},
+								"validate_only":       {"false"},
+							},
+							Body: mock.NewStructBody(models.DeploymentUpdateRequest{
+								PruneOrphans: ec.Bool(false),
+								Metadata: &models.DeploymentUpdateMetadata{Tags: []*models.MetadataItem{
+									newTag("team", "search"),
+									newTag("env", "prod"),
+								}},
+							}),
+						},
+						mock.NewStructBody(models.DeploymentUpdateResponse{ID: ec.String(id)}),
+					),
+				}},
+			},
+			want: testutils.Assertion{Stdout: "KEY    VALUE\n" +
+				"team   search\n" +
+				"env    prod\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initTagAddFlags()
+		})
+	}
+}
@@ -0,0 +1,119 @@
+resource "ec_deployment_extension" "synonyms" {
+  name           = "synonyms"
+  description    = "Synonym files"
+  version        = "*"
+  extension_type = "bundle"
+}
+
+resource "ec_deployment_traffic_filter" "office" {
+  name               = "office"
+  region             = "us-east-1"
+  type               = "ip"
+  include_by_default = false
+
+  rule {
+    source      = "192.168.0.0/24"
+    description = "office network"
+  }
+
+  rule {
+    source = "10.0.0.1"
+  }
+}
+
+resource "ec_deployment" "my_deployment" {
+  name                   = "My Deployment"
+  alias                  = "my-deployment"
+  region                 = "us-east-1"
+  version                = "8.13.2"
+  deployment_template_id = "aws-io-optimized-v2"
+
+  tags = {
+    team          = "search"
+    "cost/center" = "1234"
+  }
+
+  elasticsearch = {
+    autoscale = true
+
+    config = {
+      user_settings_yaml = <<-EOT
+        action.auto_create_index: false
+        xpack.security.authc:
+          token.enabled: true
+        EOT
+    }
+
+    hot = {
+      size       = "8g"
+      zone_count = 2
+
+      autoscaling = {
+        max_size = "116g"
+      }
+    }
+
+    ml = {
+      size       = "0g"
+      zone_count = 1
+
+      autoscaling = {
+        max_size = "60g"
+      }
+    }
+
+    extension = [
+      {
+        name    = ec_deployment_extension.synonyms.name
+        type    = "bundle"
+        version = "8.13.2"
+        url     = ec_deployment_extension.synonyms.url
+      },
+      {
+        name    = "analysis"
+        type    = "plugin"
+        version = "8.13.2"
+        url     = "https://example.com/analysis.zip"
+      },
+    ]
+  }
+
+  kibana = {
+    size       = "1g"
+    zone_count = 1
+
+    config = {
+      user_settings_yaml = "server.publicBaseUrl: \"https://kibana.example.com\"\n"
+    }
+  }
+
+  integrations_server = {
+    ref_id     = "integrations"
+    size       = "0.5g"
+    zone_count = 1
+  }
+
+  lifecycle {
+    ignore_changes = [traffic_filter]
+  }
+}
+
+resource "ec_deployment_traffic_filter_association" "office" {
+  traffic_filter_id = ec_deployment_traffic_filter.office.id
+  deployment_id     = ec_deployment.my_deployment.id
+}
+
+import {
+  to = ec_deployment.my_deployment
+  id = "5c17ad7c8df73206baa54b6e2829d9bc"
+}
+
+import {
+  to = ec_deployment_extension.synonyms
+  id = "2649887448"
+}
+
+import {
+  to = ec_deployment_traffic_filter.office
+  id = "4e974d9476534d35b12fbdcfd0acee0a"
+}
@@ -0,0 +1,419 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deploymentsize"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+)
+
+const defaultTopologyTier = "hot_content"
+
+// topologyFlags contains the parsed values of the topology sizing flags. Sizes
+// are expressed in megabytes and nil values represent flags which weren't set.
+type topologyFlags struct {
+	tier         string
+	size         *int32
+	zones        *int32
+	autoscaleMax *int32
+	kibanaSize   *int32
+	kibanaZones  *int32
+
+	integrationsServerSize  *int32
+	integrationsServerZones *int32
+	enterpriseSearchSize    *int32
+	enterpriseSearchZones   *int32
+}
+
+// elasticsearchChanged returns true when any of the Elasticsearch flags is set.
+func (f topologyFlags) elasticsearchChanged() bool {
+	return f.size != nil || f.zones != nil || f.autoscaleMax != nil
+}
+
+// kibanaChanged returns true when any of the Kibana flags is set.
+func (f topologyFlags) kibanaChanged() bool {
+	return f.kibanaSize != nil || f.kibanaZones != nil
+}
+
+// integrationsServerChanged returns true when any of the Integrations Server
+// flags is set.
+func (f topologyFlags) integrationsServerChanged() bool {
+	return f.integrationsServerSize != nil || f.integrationsServerZones != nil
+}
+
+// enterpriseSearchChanged returns true when any of the Enterprise Search flags
+// is set.
+func (f topologyFlags) enterpriseSearchChanged() bool {
+	return f.enterpriseSearchSize != nil || f.enterpriseSearchZones != nil
+}
+
+// changed returns true when any of the topology flags is set.
+func (f topologyFlags) changed() bool {
+	return f.elasticsearchChanged() || f.kibanaChanged() ||
+		f.integrationsServerChanged() || f.enterpriseSearchChanged()
+}
+
+// topologyConstraints contains the limits used to validate the topology flags,
+// obtained from a deployment template. Missing entries aren't validated.
+type topologyConstraints struct {
+	// minSize, autoscaleMin and autoscaleMax are indexed by Elasticsearch
+	// topology element ID.
+	minSize      map[string]int32
+	autoscaleMin map[string]int32
+	autoscaleMax map[string]int32
+
+	// maxSize, sizes and maxZones are indexed by instance configuration ID.
+	maxSize  map[string]int32
+	sizes    map[string][]int32
+	maxZones map[string]int32
+}
+
+// topologyResources contains the deployment resource payloads to which the
+// topology flags are applied.
+type topologyResources struct {
+	elasticsearch      []*models.ElasticsearchPayload
+	kibana             []*models.KibanaPayload
+	integrationsServer []*models.IntegrationsServerPayload
+	enterpriseSearch   []*models.EnterpriseSearchPayload
+}
+
+// addTopologyFlags adds the topology sizing flags to the specified command.
+func addTopologyFlags(cmd *cobra.Command) {
+	cmd.Flags().String("tier", defaultTopologyTier, "Elasticsearch topology element ID to which the --size, --zones and --autoscale-max flags are applied")
+	cmd.Flags().String("size", "", "Memory size per zone for the Elasticsearch tier, such as 8g or 0.5g. Use 0 to disable the tier")
+	cmd.Flags().Int32("zones", 0, "Number of zones for the Elasticsearch tier")
+	cmd.Flags().String("autoscale-max", "", "Maximum memory size per zone the Elasticsearch tier can be autoscaled to, such as 64g")
+	cmd.Flags().String("kibana-size", "", "Memory size per zone for Kibana, such as 1g")
+	cmd.Flags().Int32("kibana-zones", 0, "Number of zones for Kibana")
+	cmd.Flags().String("integrations-server-size", "", "Memory size per zone for Integrations Server, such as 1g. Use 0 to disable it")
+	cmd.Flags().Int32("integrations-server-zones", 0, "Number of zones for Integrations Server")
+	cmd.Flags().String("enterprise-search-size", "", "Memory size per zone for Enterprise Search, such as 2g. Use 0 to disable it")
+	cmd.Flags().Int32("enterprise-search-zones", 0, "Number of zones for Enterprise Search")
+}
+
+// parseTopologyFlags reads and parses the values of the topology sizing flags.
+func parseTopologyFlags(cmd *cobra.Command) (topologyFlags, error) {
+	var merr = multierror.NewPrefixed("invalid topology flags")
+	var flags topologyFlags
+	flags.tier, _ = cmd.Flags().GetString("tier")
+
+	for name, dst := range map[string]**int32{
+		"size":          &flags.size,
+		"autoscale-max": &flags.autoscaleMax,
+		"kibana-size":   &flags.kibanaSize,
+
+		"integrations-server-size": &flags.integrationsServerSize,
+		"enterprise-search-size":   &flags.enterpriseSearchSize,
+	} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		raw, _ := cmd.Flags().GetString(name)
+		size, err := deploymentsize.ParseGb(raw)
+		if err != nil {
+			merr = merr.Append(fmt.Errorf("--%s: %w", name, err))
+			continue
+		}
+		*dst = ec.Int32(size)
+	}
+
+	for name, dst := range map[string]**int32{
+		"zones":        &flags.zones,
+		"kibana-zones": &flags.kibanaZones,
+
+		"integrations-server-zones": &flags.integrationsServerZones,
+		"enterprise-search-zones":   &flags.enterpriseSearchZones,
+	} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		zones, _ := cmd.Flags().GetInt32(name)
+		if zones < 1 {
+			merr = merr.Append(fmt.Errorf("--%s: zone count must be at least 1", name))
+			continue
+		}
+		*dst = ec.Int32(zones)
+	}
+
+	return flags, merr.ErrorOrNil()
+}
+
+// newTopologyConstraints builds the topology constraints from a deployment
+// template. A nil template results in no constraints being enforced other
+// than the topology element's own TopologyElementControl minimum.
+func newTopologyConstraints(tpl *models.DeploymentTemplateInfoV2) topologyConstraints {
+	var c = topologyConstraints{
+		minSize:      make(map[string]int32),
+		autoscaleMin: make(map[string]int32),
+		autoscaleMax: make(map[string]int32),
+		maxSize:      make(map[string]int32),
+		sizes:        make(map[string][]int32),
+		maxZones:     make(map[string]int32),
+	}
+	if tpl == nil {
+		return c
+	}
+
+	for _, ic := range tpl.InstanceConfigurations {
+		if ic.MaxZones > 0 {
+			c.maxZones[ic.ID] = ic.MaxZones
+		}
+		if ic.DiscreteSizes == nil {
+			continue
+		}
+		c.sizes[ic.ID] = ic.DiscreteSizes.Sizes
+		for _, size := range ic.DiscreteSizes.Sizes {
+			if size > c.maxSize[ic.ID] {
+				c.maxSize[ic.ID] = size
+			}
+		}
+	}
+
+	if tpl.DeploymentTemplate == nil || tpl.DeploymentTemplate.Resources == nil {
+		return c
+	}
+
+	for _, es := range tpl.DeploymentTemplate.Resources.Elasticsearch {
+		if es.Plan == nil {
+			continue
+		}
+		for _, t := range es.Plan.ClusterTopology {
+			if v := topologySizeValue(t.AutoscalingMin); v != nil {
+				c.autoscaleMin[t.ID] = *v
+			}
+			if v := topologySizeValue(t.AutoscalingMax); v != nil {
+				c.autoscaleMax[t.ID] = *v
+			}
+			if t.TopologyElementControl == nil {
+				continue
+			}
+			if v := topologySizeValue(t.TopologyElementControl.Min); v != nil {
+				c.minSize[t.ID] = *v
+			}
+		}
+	}
+
+	return c
+}
+
+// applyTopologyFlags applies the topology flags to the deployment resources,
+// validating the values against the specified constraints.
+func applyTopologyFlags(flags topologyFlags, c topologyConstraints, res topologyResources) error {
+	var merr = multierror.NewPrefixed("invalid topology flags")
+	if flags.elasticsearchChanged() {
+		merr = merr.Append(applyElasticsearchTopology(flags, c, res.elasticsearch))
+	}
+
+	if flags.kibanaChanged() {
+		merr = merr.Append(applyKibanaTopology(flags, c, res.kibana))
+	}
+
+	if flags.integrationsServerChanged() {
+		merr = merr.Append(applyIntegrationsServerTopology(flags, c, res.integrationsServer))
+	}
+
+	if flags.enterpriseSearchChanged() {
+		merr = merr.Append(applyEnterpriseSearchTopology(flags, c, res.enterpriseSearch))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func applyElasticsearchTopology(flags topologyFlags, c topologyConstraints, es []*models.ElasticsearchPayload) error {
+	element, err := findTopologyElement(es, flags.tier)
+	if err != nil {
+		return err
+	}
+
+	var merr = multierror.NewPrefixed(fmt.Sprintf("tier %s", flags.tier))
+	if flags.size != nil {
+		minSize := c.minSize[element.ID]
+		if element.TopologyElementControl != nil {
+			if v := topologySizeValue(element.TopologyElementControl.Min); v != nil && *v > minSize {
+				minSize = *v
+			}
+		}
+		if *flags.size < minSize {
+			merr = merr.Append(fmt.Errorf("size %s is lower than the minimum allowed size %s",
+				formatTopologySize(*flags.size), formatTopologySize(minSize),
+			))
+		}
+		merr = merr.Append(validateMaxSize(*flags.size, c.maxSize[element.InstanceConfigurationID]))
+		element.Size = newTopologySize(element.Size, *flags.size)
+	}
+
+	if flags.zones != nil {
+		merr = merr.Append(validateZones(*flags.zones, c.maxZones[element.InstanceConfigurationID]))
+		element.ZoneCount = *flags.zones
+	}
+
+	if flags.autoscaleMax != nil {
+		if current := topologySizeValue(element.Size); current != nil && *flags.autoscaleMax < *current {
+			merr = merr.Append(fmt.Errorf("autoscale max size %s is lower than the tier size %s",
+				formatTopologySize(*flags.autoscaleMax), formatTopologySize(*current),
+			))
+		}
+		if max, ok := c.autoscaleMax[element.ID]; ok && *flags.autoscaleMax > max {
+			merr = merr.Append(fmt.Errorf("autoscale max size %s is higher than the maximum allowed size %s",
+				formatTopologySize(*flags.autoscaleMax), formatTopologySize(max),
+			))
+		}
+		element.AutoscalingMax = newTopologySize(element.AutoscalingMax, *flags.autoscaleMax)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// findTopologyElement returns the topology element of the first Elasticsearch
+// resource which matches the specified tier.
+func findTopologyElement(es []*models.ElasticsearchPayload, tier string) (*models.ElasticsearchClusterTopologyElement, error) {
+	if len(es) == 0 || es[0].Plan == nil {
+		return nil, errors.New("the deployment has no Elasticsearch resource")
+	}
+
+	var ids = make([]string, 0, len(es[0].Plan.ClusterTopology))
+	for _, t := range es[0].Plan.ClusterTopology {
+		if t.ID == tier {
+			return t, nil
+		}
+		ids = append(ids, t.ID)
+	}
+	return nil, fmt.Errorf(`tier "%s" not found, available tiers: %s`, tier, strings.Join(ids, ", "))
+}
+
+func applyKibanaTopology(flags topologyFlags, c topologyConstraints, kibana []*models.KibanaPayload) error {
+	if len(kibana) == 0 || kibana[0].Plan == nil || len(kibana[0].Plan.ClusterTopology) == 0 {
+		return errors.New("the deployment has no Kibana resource")
+	}
+
+	element := kibana[0].Plan.ClusterTopology[0]
+	return applyStatelessTopology("kibana", c, element.InstanceConfigurationID,
+		flags.kibanaSize, flags.kibanaZones, &element.Size, &element.ZoneCount,
+	)
+}
+
+func applyIntegrationsServerTopology(flags topologyFlags, c topologyConstraints, integrationsServer []*models.IntegrationsServerPayload) error {
+	if len(integrationsServer) == 0 || integrationsServer[0].Plan == nil || len(integrationsServer[0].Plan.ClusterTopology) == 0 {
+		return errors.New("the deployment has no Integrations Server resource")
+	}
+
+	element := integrationsServer[0].Plan.ClusterTopology[0]
+	return applyStatelessTopology("integrations server", c, element.InstanceConfigurationID,
+		flags.integrationsServerSize, flags.integrationsServerZones, &element.Size, &element.ZoneCount,
+	)
+}
+
+func applyEnterpriseSearchTopology(flags topologyFlags, c topologyConstraints, enterpriseSearch []*models.EnterpriseSearchPayload) error {
+	if len(enterpriseSearch) == 0 || enterpriseSearch[0].Plan == nil || len(enterpriseSearch[0].Plan.ClusterTopology) == 0 {
+		return errors.New("the deployment has no Enterprise Search resource")
+	}
+
+	element := enterpriseSearch[0].Plan.ClusterTopology[0]
+	return applyStatelessTopology("enterprise search", c, element.InstanceConfigurationID,
+		flags.enterpriseSearchSize, flags.enterpriseSearchZones, &element.Size, &element.ZoneCount,
+	)
+}
+
+// applyStatelessTopology sets the size and zone count of the topology element
+// of a stateless resource, such as Kibana. Since these resources can only be
+// sized to one of the instance configuration's discrete sizes, any other size
+// than those or 0 is rejected.
+func applyStatelessTopology(kind string, c topologyConstraints, instanceConfigurationID string, size, zones *int32, elementSize **models.TopologySize, elementZones *int32) error {
+	var merr = multierror.NewPrefixed(kind)
+	if size != nil {
+		if err := validateMaxSize(*size, c.maxSize[instanceConfigurationID]); err != nil {
+			merr = merr.Append(err)
+		} else {
+			merr = merr.Append(validateDiscreteSize(*size, c.sizes[instanceConfigurationID]))
+		}
+		*elementSize = newTopologySize(*elementSize, *size)
+	}
+
+	if zones != nil {
+		merr = merr.Append(validateZones(*zones, c.maxZones[instanceConfigurationID]))
+		*elementZones = *zones
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func validateMaxSize(size, max int32) error {
+	if max > 0 && size > max {
+		return fmt.Errorf("size %s is higher than the maximum allowed size %s",
+			formatTopologySize(size), formatTopologySize(max),
+		)
+	}
+	return nil
+}
+
+func validateDiscreteSize(size int32, sizes []int32) error {
+	if size == 0 || len(sizes) == 0 {
+		return nil
+	}
+
+	var allowed = make([]string, 0, len(sizes))
+	for _, s := range sizes {
+		if s == size {
+			return nil
+		}
+		allowed = append(allowed, formatTopologySize(s))
+	}
+	return fmt.Errorf("size %s is not one of the allowed sizes: %s",
+		formatTopologySize(size), strings.Join(allowed, ", "),
+	)
+}
+
+func validateZones(zones, max int32) error {
+	if max > 0 && zones > max {
+		return fmt.Errorf("zone count %d is higher than the maximum allowed zone count %d", zones, max)
+	}
+	return nil
+}
+
+// newTopologySize returns a new TopologySize with the specified value, keeping
+// the resource type of the current size when set.
+func newTopologySize(current *models.TopologySize, value int32) *models.TopologySize {
+	var resource = models.TopologySizeResourceMemory
+	if current != nil && current.Resource != nil {
+		resource = *current.Resource
+	}
+	return &models.TopologySize{
+		Resource: ec.String(resource),
+		Value:    ec.Int32(value),
+	}
+}
+
+func topologySizeValue(size *models.TopologySize) *int32 {
+	if size == nil {
+		return nil
+	}
+	return size.Value
+}
+
+// formatTopologySize formats a size in megabytes to the <size>g notation.
+func formatTopologySize(size int32) string {
+	return strconv.FormatFloat(float64(size)/1024, 'f', -1, 32) + "g"
+}
@@ -0,0 +1,211 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseTopologyFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want topologyFlags
+		err  string
+	}{
+		{
+			name: "returns the default tier when no flags are set",
+			want: topologyFlags{tier: "hot_content"},
+		},
+		{
+			name: "parses all the flags",
+			args: []string{
+				"--tier=warm", "--size=8g", "--zones=3", "--autoscale-max=64g",
+				"--kibana-size=0.5g", "--kibana-zones=2",
+				"--integrations-server-size=1g", "--integrations-server-zones=1",
+				"--enterprise-search-size=0", "--enterprise-search-zones=3",
+			},
+			want: topologyFlags{
+				tier:         "warm",
+				size:         ec.Int32(8192),
+				zones:        ec.Int32(3),
+				autoscaleMax: ec.Int32(65536),
+				kibanaSize:   ec.Int32(512),
+				kibanaZones:  ec.Int32(2),
+
+				integrationsServerSize:  ec.Int32(1024),
+				integrationsServerZones: ec.Int32(1),
+				enterpriseSearchSize:    ec.Int32(0),
+				enterpriseSearchZones:   ec.Int32(3),
+			},
+		},
+		{
+			name: "parses a zero size",
+			args: []string{"--size=0"},
+			want: topologyFlags{tier: "hot_content", size: ec.Int32(0)},
+		},
+		{
+			name: "fails on invalid values",
+			args: []string{"--size=8", "--zones=0"},
+			err: "invalid topology flags: 2 errors occurred:\n" +
+				"\t* --size: failed to convert \"8\" to <size><g>\n" +
+				"\t* --zones: zone count must be at least 1\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addTopologyFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			got, err := parseTopologyFlags(cmd)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_applyTopologyFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags  topologyFlags
+		assert func(t *testing.T, payload *models.DeploymentCreateRequest)
+		err    string
+	}{
+		{
+			name: "applies the Elasticsearch and Kibana flags",
+			flags: topologyFlags{
+				tier:         "hot_content",
+				size:         ec.Int32(16384),
+				zones:        ec.Int32(3),
+				autoscaleMax: ec.Int32(65536),
+				kibanaSize:   ec.Int32(2048),
+				kibanaZones:  ec.Int32(2),
+			},
+			assert: func(t *testing.T, payload *models.DeploymentCreateRequest) {
+				hot := payload.Resources.Elasticsearch[0].Plan.ClusterTopology[1]
+				assert.Equal(t, int32(16384), *hot.Size.Value)
+				assert.Equal(t, "memory", *hot.Size.Resource)
+				assert.Equal(t, int32(3), hot.ZoneCount)
+				assert.Equal(t, int32(65536), *hot.AutoscalingMax.Value)
+
+				kibana := payload.Resources.Kibana[0].Plan.ClusterTopology[0]
+				assert.Equal(t, int32(2048), *kibana.Size.Value)
+				assert.Equal(t, int32(2), kibana.ZoneCount)
+			},
+		},
+		{
+			name:  "enables a disabled tier",
+			flags: topologyFlags{tier: "warm", size: ec.Int32(4096)},
+			assert: func(t *testing.T, payload *models.DeploymentCreateRequest) {
+				warm := payload.Resources.Elasticsearch[0].Plan.ClusterTopology[2]
+				assert.Equal(t, int32(4096), *warm.Size.Value)
+			},
+		},
+		{
+			name: "applies the Enterprise Search flags",
+			flags: topologyFlags{
+				tier:                  "hot_content",
+				enterpriseSearchSize:  ec.Int32(4096),
+				enterpriseSearchZones: ec.Int32(1),
+			},
+			assert: func(t *testing.T, payload *models.DeploymentCreateRequest) {
+				enterpriseSearch := payload.Resources.EnterpriseSearch[0].Plan.ClusterTopology[0]
+				assert.Equal(t, int32(4096), *enterpriseSearch.Size.Value)
+				assert.Equal(t, int32(1), enterpriseSearch.ZoneCount)
+			},
+		},
+		{
+			name: "fails when the stateless sizes are not allowed by the template",
+			flags: topologyFlags{
+				tier:                 "hot_content",
+				kibanaSize:           ec.Int32(1536),
+				enterpriseSearchSize: ec.Int32(16384),
+			},
+			err: "invalid topology flags: 2 errors occurred:\n" +
+				"\t* enterprise search: size 16g is higher than the maximum allowed size 8g\n" +
+				"\t* kibana: size 1.5g is not one of the allowed sizes: 1g, 2g, 4g, 8g\n\n",
+		},
+		{
+			name:  "fails when the template has no Integrations Server resource",
+			flags: topologyFlags{tier: "hot_content", integrationsServerSize: ec.Int32(1024)},
+			err: "invalid topology flags: 1 error occurred:\n" +
+				"\t* the deployment has no Integrations Server resource\n\n",
+		},
+		{
+			name:  "fails when the tier does not exist",
+			flags: topologyFlags{tier: "lukewarm", size: ec.Int32(4096)},
+			err: "invalid topology flags: 1 error occurred:\n" +
+				"\t* tier \"lukewarm\" not found, available tiers: coordinating, hot_content, warm, cold, master, ml\n\n",
+		},
+		{
+			name: "fails when the values are outside of the template limits",
+			flags: topologyFlags{
+				tier:         "hot_content",
+				size:         ec.Int32(512),
+				autoscaleMax: ec.Int32(262144),
+			},
+			err: "invalid topology flags: 2 errors occurred:\n" +
+				"\t* tier hot_content: autoscale max size 256g is higher than the maximum allowed size 116g\n" +
+				"\t* tier hot_content: size 0.5g is lower than the minimum allowed size 1g\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tpl models.DeploymentTemplateInfoV2
+			require.NoError(t, tpl.UnmarshalBinary(awsIoOptimisedTemplate))
+
+			constraints := newTopologyConstraints(&tpl)
+			payload := tpl.DeploymentTemplate
+			err := applyTopologyFlags(tt.flags, constraints, topologyResources{
+				elasticsearch:      payload.Resources.Elasticsearch,
+				kibana:             payload.Resources.Kibana,
+				integrationsServer: payload.Resources.IntegrationsServer,
+				enterpriseSearch:   payload.Resources.EnterpriseSearch,
+			})
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			tt.assert(t, payload)
+		})
+	}
+}
+
+func Test_newScaleRequest(t *testing.T) {
+	var res models.DeploymentGetResponse
+	require.NoError(t, res.UnmarshalBinary(showApmResp))
+
+	got := newScaleRequest(&res, topologyFlags{kibanaSize: ec.Int32(2048)})
+	assert.Len(t, got.Resources.Kibana, 1)
+	assert.Empty(t, got.Resources.Elasticsearch)
+	assert.Empty(t, got.Resources.Apm)
+	assert.Nil(t, got.Settings)
+	assert.False(t, *got.PruneOrphans)
+}
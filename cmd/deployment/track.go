@@ -0,0 +1,423 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const trackLong = `Tracks the pending plan changes of one or more deployments in parallel, until all
+of them have finished. The deployment IDs are either specified as arguments, or read
+from stdin, separated by spaces or new lines, when no arguments or "-" are given.
+
+While the plans are being tracked, the current step and elapsed time of each of the
+deployment resources is displayed. When stderr is a terminal, the status is refreshed
+in place, otherwise a line is written every time a resource changes its step. When
+the output is json or ndjson, or --track-format is ndjson, the progress is written
+to stdout as JSON tracking events, one per line, instead of the summary.
+
+Once all the plans have finished, a summary is displayed with the status of each
+resource and any plan errors. A non-zero exit code is returned when any of the plans
+failed.`
+
+const trackExample = `## Track the pending plan changes of two deployments.
+$ ecctl deployment track 5c17ad7c8df73206baa54b6e2829d9bc 1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10
+
+## Track the pending plan changes of the deployment IDs listed in a file.
+$ cat ids.txt | ecctl deployment track -`
+
+// Statuses of the resources tracked by "ecctl deployment track".
+const (
+	trackWaiting   = "waiting"
+	trackRunning   = "running"
+	trackSucceeded = "succeeded"
+	trackFailed    = "failed"
+)
+
+// trackResult represents the plan status of a tracked deployment resource.
+// Deployments which haven't yet reported any resource plan are represented by
+// a single result with an empty Kind.
+type trackResult struct {
+	DeploymentID string `json:"deployment_id"`
+	Kind         string `json:"kind,omitempty"`
+	RefID        string `json:"ref_id,omitempty"`
+	Step         string `json:"step,omitempty"`
+	Status       string `json:"status"`
+	Duration     string `json:"duration,omitempty"`
+	Error        string `json:"error,omitempty"`
+
+	resourceID string
+}
+
+// line returns the tab separated representation of the result.
+func (r trackResult) line() string {
+	var kind, refID, step, duration = "-", "-", "-", "-"
+	if r.Kind != "" {
+		kind, refID = r.Kind, r.RefID
+	}
+	if r.Step != "" {
+		step = r.Step
+	}
+	if r.Duration != "" {
+		duration = r.Duration
+	}
+
+	var line = strings.Join([]string{r.DeploymentID, kind, refID, r.Status, step, duration}, "\t")
+	if r.Error != "" {
+		line += "\t" + r.Error
+	}
+	return line
+}
+
+// trackSummary contains the outcome of the tracked plans. The counts refer to
+// deployments, a deployment is considered failed when any of its resource
+// plans failed.
+type trackSummary struct {
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []trackResult `json:"results"`
+}
+
+var trackCmd = &cobra.Command{
+	Use:     "track {<deployment id>... | - < ids}",
+	Short:   "Tracks the pending plan changes of one or more deployments",
+	Long:    trackLong,
+	Example: trackExample,
+	PreRunE: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := readTrackIDs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		maxRetries, pollFrequency := cmdutil.GetTrackSettings(cmd)
+		var frequency = plan.TrackFrequencyConfig{
+			PollFrequency: pollFrequency,
+			MaxRetries:    maxRetries,
+		}
+
+		var events *cmdutil.TrackEventWriter
+		if cmdutil.TrackFormat(ecctl.Get().Config) == ecctl.NDJSONOutput {
+			events = cmdutil.NewTrackEventWriter(ecctl.Get().Config.OutputDevice)
+		}
+
+		summary := trackDeployments(ids, cmd.ErrOrStderr(), events, func(id string) plan.TrackChangeParams {
+			return cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+				App:             ecctl.Get(),
+				DeploymentID:    id,
+				FrequencyConfig: &frequency,
+			}).TrackChangeParams.TrackChangeParams
+		})
+
+		// The result events already contain the outcome of each deployment.
+		if events == nil {
+			if err := ecctl.Get().Formatter.Format("deployment/track", summary); err != nil {
+				return err
+			}
+		}
+
+		if summary.Failed > 0 {
+			return fmt.Errorf("plan changes failed in %d of %d deployments", summary.Failed, len(ids))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	initTrackFlags()
+}
+
+func initTrackFlags() {
+	Command.AddCommand(trackCmd)
+	cmdutil.AddTrackFlags(trackCmd)
+}
+
+// readTrackIDs returns the deployment IDs specified as arguments, or read from
+// the command's input when no arguments or "-" are specified.
+func readTrackIDs(cmd *cobra.Command, args []string) ([]string, error) {
+	var r io.Reader = strings.NewReader(strings.Join(args, " "))
+	if len(args) == 0 || (len(args) == 1 && args[0] == "-") {
+		r = cmd.InOrStdin()
+	}
+
+	targets, err := readBulkTargets(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.New("no deployment IDs specified")
+	}
+
+	var ids = make([]string, 0, len(targets))
+	for _, t := range targets {
+		ids = append(ids, t.ID)
+	}
+
+	return ids, nil
+}
+
+// trackDeployments tracks the pending plans of all the deployments in
+// parallel, rendering their progress to the writer, and returns a summary
+// once all of them have finished. When events is set, the progress is written
+// as tracking events instead.
+func trackDeployments(ids []string, w io.Writer, events *cmdutil.TrackEventWriter, newParams func(id string) plan.TrackChangeParams) trackSummary {
+	if events != nil {
+		w = io.Discard
+	}
+
+	var board = newTrackBoard(ids, w, isTerminal(w))
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			var started = time.Now()
+			if events != nil {
+				started = events.Now()
+			}
+
+			channel, err := plan.TrackChange(newParams(id))
+			if err == nil {
+				err = plan.StreamFunc(channel, func(res plan.TrackResponse) {
+					board.update(res)
+					if events != nil {
+						events.Step(res)
+					}
+				})
+				board.finish(id)
+			} else {
+				board.fail(id, err)
+			}
+
+			if events != nil {
+				events.Result(id, started, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	return board.summary()
+}
+
+// trackBoard keeps the plan status of the tracked deployment resources,
+// rendering it to the writer on every update. When live is set, the whole
+// board is redrawn in place, otherwise only the results which changed are
+// written.
+type trackBoard struct {
+	mu      sync.Mutex
+	w       io.Writer
+	live    bool
+	drawn   int
+	ids     []string
+	results []trackResult
+}
+
+func newTrackBoard(ids []string, w io.Writer, live bool) *trackBoard {
+	var board = &trackBoard{w: w, live: live, ids: ids}
+	for _, id := range ids {
+		board.results = append(board.results, trackResult{
+			DeploymentID: id, Status: trackWaiting,
+		})
+	}
+
+	if live {
+		board.redraw()
+	}
+
+	return board
+}
+
+// update records the plan status received in the response.
+func (b *trackBoard) update(res plan.TrackResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var i = b.index(res.DeploymentID, res.ID)
+	var prev = b.results[i]
+	var r = &b.results[i]
+	r.Kind, r.RefID, r.resourceID = res.Kind, res.RefID, res.ID
+	r.Status = trackRunning
+	r.Duration = time.Duration(res.Duration).Round(time.Second).String()
+	if res.Step != "" {
+		r.Step = res.Step
+	}
+	var failed = res.Err != nil && res.Err != plan.ErrPlanFinished
+	if failed {
+		r.Error = cmdutil.FlattenTrackError(res.Err)
+	}
+	if res.Finished {
+		r.Status = trackSucceeded
+		if failed {
+			r.Status = trackFailed
+		}
+	}
+
+	if b.live {
+		b.redraw()
+		return
+	}
+
+	if r.Step != prev.Step || r.Status != prev.Status || r.Error != prev.Error {
+		b.write(*r)
+	}
+}
+
+// fail marks the deployment as failed when its plan couldn't be tracked.
+func (b *trackBoard) fail(id string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var i = b.placeholder(id)
+	if i < 0 {
+		i = b.index(id, "")
+	}
+	b.results[i].Status = trackFailed
+	b.results[i].Error = cmdutil.FlattenTrackError(err)
+	b.render(b.results[i])
+}
+
+// finish marks the deployment as succeeded when none of its resources
+// reported a plan status.
+func (b *trackBoard) finish(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var i = b.placeholder(id)
+	if i < 0 {
+		return
+	}
+
+	b.results[i].Status = trackSucceeded
+	b.render(b.results[i])
+}
+
+// index returns the index of the deployment resource result. The placeholder
+// result of the deployment is reused for its first resource, and any further
+// resources are inserted after the deployment's last result, keeping the
+// results grouped by deployment.
+func (b *trackBoard) index(id, resourceID string) int {
+	var last = -1
+	for i, r := range b.results {
+		if r.DeploymentID != id {
+			continue
+		}
+		if r.resourceID == resourceID || r.resourceID == "" {
+			return i
+		}
+		last = i
+	}
+
+	var i = last + 1
+	b.results = append(b.results, trackResult{})
+	copy(b.results[i+1:], b.results[i:])
+	b.results[i] = trackResult{DeploymentID: id, resourceID: resourceID}
+
+	return i
+}
+
+// placeholder returns the index of the deployment's placeholder result, or -1
+// when any of its resources has already reported a plan status.
+func (b *trackBoard) placeholder(id string) int {
+	for i, r := range b.results {
+		if r.DeploymentID == id && r.resourceID == "" && r.Status == trackWaiting {
+			return i
+		}
+	}
+	return -1
+}
+
+// render writes the updated result, or redraws the board when live.
+func (b *trackBoard) render(r trackResult) {
+	if b.live {
+		b.redraw()
+		return
+	}
+	b.write(r)
+}
+
+func (b *trackBoard) write(r trackResult) {
+	var tw = tabwriter.NewWriter(b.w, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, r.line())
+	_ = tw.Flush()
+}
+
+// redraw moves the cursor back to the start of the board and writes all the
+// results, clearing the previously drawn lines.
+func (b *trackBoard) redraw() {
+	var buf bytes.Buffer
+	if b.drawn > 0 {
+		fmt.Fprintf(&buf, "\033[%dA", b.drawn)
+	}
+
+	var tw = tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+	for _, r := range b.results {
+		_, _ = fmt.Fprintf(tw, "\033[2K%s\n", r.line())
+	}
+	_ = tw.Flush()
+
+	_, _ = b.w.Write(buf.Bytes())
+	b.drawn = len(b.results)
+}
+
+// summary returns the results grouped by deployment in the order they were
+// specified, counting the succeeded and failed deployments.
+func (b *trackBoard) summary() trackSummary {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var summary = trackSummary{Results: b.results}
+	for _, id := range b.ids {
+		var failed bool
+		for _, r := range b.results {
+			if r.DeploymentID == id && r.Status == trackFailed {
+				failed = true
+			}
+		}
+
+		if failed {
+			summary.Failed++
+			continue
+		}
+		summary.Succeeded++
+	}
+
+	return summary
+}
+
+// isTerminal returns true when the writer is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	planmock "github.com/elastic/cloud-sdk-go/pkg/plan/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+const (
+	trackFirstID  = "5c17ad7c8df73206baa54b6e2829d9bc"
+	trackSecondID = "1eb4f2ff8ea3c5a1d3d8e5b8f7de0a10"
+)
+
+func newTrackPlanResponse(id string, pending, current []*models.ClusterPlanStepInfo) mock.Response {
+	return mock.New200StructResponse(planmock.Generate(planmock.GenerateConfig{
+		ID: id,
+		Elasticsearch: []planmock.GeneratedResourceConfig{
+			{ID: "cde7b6b605424a54ce9d56316eab13a1", PendingLog: pending, CurrentLog: current},
+		},
+	}))
+}
+
+func newTrackSucceededResponses(id string) []mock.Response {
+	return []mock.Response{
+		newTrackPlanResponse(id, planmock.NewPlanStepLog(
+			planmock.NewPlanStep("step-1", "pending"),
+		), nil),
+		newTrackPlanResponse(id, nil, planmock.NewPlanStepLog(
+			planmock.NewPlanStep("plan-completed", "success"),
+		)),
+		newTrackPlanResponse(id, nil, planmock.NewPlanStepLog(
+			planmock.NewPlanStep("plan-completed", "success"),
+		)),
+	}
+}
+
+func Test_trackDeployments(t *testing.T) {
+	var apis = map[string]*api.API{
+		trackFirstID: api.NewMock(newTrackSucceededResponses(trackFirstID)...),
+		trackSecondID: api.NewMock(
+			newTrackPlanResponse(trackSecondID, nil, nil),
+			newTrackPlanResponse(trackSecondID, nil, planmock.NewPlanStepLog(
+				planmock.NewPlanStepWithDetailsAndError("plan-completed", []*models.ClusterPlanStepLogMessageInfo{
+					{Message: ec.String("horrible failure")},
+				}),
+			)),
+		),
+	}
+
+	var out bytes.Buffer
+	got := trackDeployments([]string{trackFirstID, trackSecondID}, &out, nil, func(id string) plan.TrackChangeParams {
+		return plan.TrackChangeParams{
+			API:          apis[id],
+			DeploymentID: id,
+			Config:       plan.TrackFrequencyConfig{PollFrequency: 1, MaxRetries: 1},
+		}
+	})
+
+	assert.Equal(t, trackSummary{Succeeded: 1, Failed: 1, Results: []trackResult{
+		{
+			DeploymentID: trackFirstID, Kind: "elasticsearch", RefID: "main-elasticsearch",
+			Step: "plan-completed", Status: trackSucceeded, Duration: "0s",
+			resourceID: "cde7b6b605424a54ce9d56316eab13a1",
+		},
+		{
+			DeploymentID: trackSecondID, Kind: "elasticsearch", RefID: "main-elasticsearch",
+			Step: "plan-completed", Status: trackFailed, Duration: "0s", Error: "horrible failure",
+			resourceID: "cde7b6b605424a54ce9d56316eab13a1",
+		},
+	}}, got)
+
+	for _, line := range []string{
+		trackFirstID + "  elasticsearch  main-elasticsearch  running  step-1  0s\n",
+		trackFirstID + "  elasticsearch  main-elasticsearch  succeeded  plan-completed  0s\n",
+		trackSecondID + "  elasticsearch  main-elasticsearch  failed  plan-completed  0s  horrible failure\n",
+	} {
+		assert.Contains(t, out.String(), line)
+	}
+}
+
+func Test_trackCmd(t *testing.T) {
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "fails on an invalid deployment ID",
+			args: testutils.Args{
+				Cmd:  trackCmd,
+				Args: []string{"track", trackFirstID, "invalid"},
+			},
+			want: testutils.Assertion{
+				Err: `invalid deployment ID "invalid": must have a length of 32 characters`,
+			},
+		},
+		{
+			name: "fails when no deployment IDs are read from stdin",
+			args: testutils.Args{
+				Cmd:  trackCmd,
+				Args: []string{"track", "-"},
+			},
+			want: testutils.Assertion{
+				Err: "no deployment IDs specified",
+			},
+		},
+		{
+			name: "tracks the pending plan of the deployment",
+			args: testutils.Args{
+				Cmd:  trackCmd,
+				Args: []string{"track", trackFirstID},
+				Cfg: testutils.MockCfg{
+					Responses:    newTrackSucceededResponses(trackFirstID),
+					OutputFormat: "text",
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "DEPLOYMENT ID                      KIND            REF ID               STATUS      DURATION   ERROR\n" +
+					trackFirstID + "   elasticsearch   main-elasticsearch   succeeded   0s         -\n" +
+					"1 succeeded, 0 failed\n",
+				Stderr: trackFirstID + "  elasticsearch  main-elasticsearch  running  step-1  0s\n" +
+					trackFirstID + "  elasticsearch  main-elasticsearch  succeeded  plan-completed  0s\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initTrackFlags()
+		})
+	}
+}
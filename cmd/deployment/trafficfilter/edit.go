@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymenttrafficfilter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/go-openapi/strfmt"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+var editCmd = &cobra.Command{
+	Use:     "edit <traffic-filter id>",
+	Short:   "Edits a traffic-filter update payload in $EDITOR and applies the changes",
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
+			API: ecctl.Get().API,
+			ID:  args[0],
+		})
+		if err != nil {
+			return err
+		}
+
+		var req *models.TrafficFilterRulesetRequest
+		format, _ := cmd.Flags().GetString("payload-format")
+		changed, err := cmdutil.EditPayload(cmdutil.EditPayloadParams{
+			Payload: trafficfilterapi.NewUpdateRequestFromGet(res),
+			Format:  format,
+			Output:  ecctl.Get().Config.OutputDevice,
+			Decode: func(data []byte) error {
+				var r models.TrafficFilterRulesetRequest
+				if err := cmdutil.DecodeStrict(data, &r); err != nil {
+					return err
+				}
+				if err := r.Validate(strfmt.Default); err != nil {
+					return err
+				}
+				req = &r
+				return nil
+			},
+		})
+		if err != nil || !changed {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		var msg = fmt.Sprintf("Do you want to apply the changes to traffic-filter %s? [y/n]: ", args[0])
+		if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+			return nil
+		}
+
+		updateRes, err := trafficfilterapi.Update(trafficfilterapi.UpdateParams{
+			API: ecctl.Get().API,
+			ID:  args[0],
+			Req: req,
+		})
+		if err != nil {
+			return err
+		}
+
+		return ecctl.Get().Formatter.Format("", updateRes)
+	},
+}
+
+func init() {
+	initEditFlags()
+}
+
+func initEditFlags() {
+	Command.AddCommand(editCmd)
+	editCmd.Flags().String("payload-format", cmdutil.PayloadFormatJSON, cmdutil.PayloadFormatFlagMessage)
+}
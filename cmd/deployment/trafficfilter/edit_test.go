@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymenttrafficfilter
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+//go:embed "testdata/show.json"
+var editShowRawResp []byte
+
+func Test_editCmd(t *testing.T) {
+	// The force flag is a persistent flag of the root command.
+	if Command.PersistentFlags().Lookup("force") == nil {
+		Command.PersistentFlags().Bool("force", false, "")
+	}
+
+	var succeedResp = new(models.TrafficFilterRulesetResponse)
+	if err := succeedResp.UnmarshalBinary(updateRawResp); err != nil {
+		t.Fatal(err)
+	}
+
+	updateJSONOutput, err := json.MarshalIndent(succeedResp, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newGetResponse := func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Method: "GET",
+				Path:   "/api/v1/deployments/traffic-filter/rulesets/4e974d9476534d35b12fbdcfd0acee0a",
+				Host:   api.DefaultMockHost,
+				Query: url.Values{
+					"include_associations": []string{"false"},
+				},
+			},
+			mock.NewByteBody(editShowRawResp),
+		)
+	}
+
+	tests := []struct {
+		name   string
+		editor string
+		args   testutils.Args
+		want   testutils.Assertion
+	}{
+		{
+			name:   "fails when the edited payload is invalid",
+			editor: "sed -i /type:/d",
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", "4e974d9476534d35b12fbdcfd0acee0a", "--payload-format", "yaml"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newGetResponse()}},
+			},
+			want: testutils.Assertion{
+				Err: "the edited payload is invalid: validation failure list:\ntype in body is required",
+			},
+		},
+		{
+			name:   "shows the diff and updates the traffic filter",
+			editor: "sed -i s/azure$/renamed/",
+			args: testutils.Args{
+				Cmd:  editCmd,
+				Args: []string{"edit", "4e974d9476534d35b12fbdcfd0acee0a", "--payload-format", "yaml", "--force"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						newGetResponse(),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/traffic-filter/rulesets/4e974d9476534d35b12fbdcfd0acee0a",
+								Body: mock.NewStringBody(
+									`{"include_by_default":false,"name":"renamed","region":"azure-eastus2","rules":[{"id":"e5f03a27777a4efc97e3c6aa4d287b9f","source":"0.0.0.0/0"}],"type":"ip"}` +
+										"\n"),
+								Host: api.DefaultMockHost,
+							},
+							mock.NewByteBody(updateRawResp),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "--- current\n+++ edited\n@@ -1,5 +1,5 @@\n include_by_default: false\n-name: azure\n+name: renamed\n" +
+					" region: azure-eastus2\n rules:\n   - id: e5f03a27777a4efc97e3c6aa4d287b9f\n" +
+					string(updateJSONOutput) + "\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", "")
+			t.Setenv("EDITOR", tt.editor)
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initEditFlags()
+		})
+	}
+}
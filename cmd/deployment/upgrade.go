@@ -0,0 +1,273 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/api/stackapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const upgradeLong = `Upgrades all the resources of a deployment to the specified version in a single plan change.
+Elasticsearch is upgraded first, followed by the resources which depend on it.
+
+Before the upgrade is started, the following checks are performed:
+
+  * The version must be available in the deployment's region.
+  * The version must be higher than the current version, and no major versions can be skipped.
+  * The current version must be upgradable to the specified version.
+  * The deployment can't contain resource kinds which aren't supported in the specified
+    version. These resources aren't removed by the upgrade, they need to be migrated
+    or removed first:
+      - APM isn't supported from 8.0.0 and needs to be migrated to Integrations Server.
+      - App Search isn't supported from 7.7.0 and needs to be migrated to Enterprise Search.
+      - Enterprise Search isn't supported from 9.0.0 and needs to be removed.`
+
+const upgradeExample = `## Upgrade a deployment to 8.15.0 and wait until all the resources have been upgraded.
+$ ecctl deployment upgrade 5c17ad7c8df73206baa54b6e2829d9bc --version 8.15.0 --track
+Upgrading elasticsearch [main-elasticsearch] from 8.14.3 to 8.15.0
+Upgrading kibana [main-kibana] from 8.14.3 to 8.15.0
+[...]`
+
+// unsupportedKinds contains the resource kinds which are no longer supported
+// starting from the specified version, along with the action which is needed
+// before an existing resource of the kind can be upgraded to that version.
+var unsupportedKinds = []struct {
+	kind   string
+	since  semver.Version
+	action string
+}{
+	{kind: "apm", since: semver.MustParse("8.0.0"), action: "migrated to Integrations Server"},
+	{kind: "appsearch", since: semver.MustParse("7.7.0"), action: "migrated to Enterprise Search"},
+	{kind: "enterprise_search", since: semver.MustParse("9.0.0"), action: "removed"},
+}
+
+// isUnsupportedKind returns true when the resource kind is no longer supported
+// in the specified version.
+func isUnsupportedKind(kind string, version semver.Version) bool {
+	for _, u := range unsupportedKinds {
+		if u.kind == kind && version.GTE(u.since) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceVersion represents the version of a deployment resource.
+type resourceVersion struct {
+	kind    string
+	refID   string
+	version string
+}
+
+var upgradeCmd = &cobra.Command{
+	Use:     "upgrade <deployment id> --version <version>",
+	Short:   "Upgrades all the resources of a deployment to the specified version",
+	Long:    upgradeLong,
+	Example: upgradeExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, _ := cmd.Flags().GetString("version")
+		target, err := semver.Parse(version)
+		if err != nil {
+			return fmt.Errorf("failed to parse version: %v", err)
+		}
+
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: args[0],
+			QueryParams: deputil.QueryParams{
+				ShowPlans:      true,
+				ShowSettings:   true,
+				ClearTransient: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		req := deploymentapi.NewUpdateRequest(res)
+		req.Settings = nil
+		versions := getResourceVersions(req)
+		if len(versions) == 0 || versions[0].kind != "elasticsearch" {
+			return errors.New("the deployment has no Elasticsearch resource to upgrade")
+		}
+
+		current, err := semver.Parse(versions[0].version)
+		if err != nil {
+			return fmt.Errorf("failed to parse the current version: %v", err)
+		}
+
+		var region = ecctl.Get().Config.Region
+		if es := req.Resources.Elasticsearch[0]; es.Region != nil && *es.Region != "" {
+			region = *es.Region
+		}
+		if region == "" {
+			region = cmdutil.DefaultECERegion
+		}
+
+		stacks, err := stackapi.List(stackapi.ListParams{
+			API:    ecctl.Get().API,
+			Region: region,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to fetch stack versions: %w", err)
+		}
+
+		if err := validateUpgradePath(current, target, region, stacks.Stacks, versions); err != nil {
+			return err
+		}
+
+		if err := deploymentapi.OverrideCreateOrUpdateRequest(req,
+			&deploymentapi.PayloadOverrides{Version: version},
+		); err != nil {
+			return err
+		}
+
+		for _, r := range versions {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Upgrading %s [%s] from %s to %s\n",
+				r.kind, r.refID, r.version, version,
+			)
+		}
+
+		updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+			DeploymentID: args[0],
+			API:          ecctl.Get().API,
+			Overrides:    deploymentapi.PayloadOverrides{Region: region},
+			Request:      req,
+		})
+		if err != nil {
+			return err
+		}
+
+		track, _ := cmd.Flags().GetBool("track")
+		return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+			App:          ecctl.Get(),
+			DeploymentID: args[0],
+			Track:        track,
+			Response:     updateRes,
+		}))
+	},
+}
+
+func init() {
+	initUpgradeFlags()
+}
+
+func initUpgradeFlags() {
+	Command.AddCommand(upgradeCmd)
+	upgradeCmd.Flags().String("version", "", "Required version to upgrade the deployment to")
+	upgradeCmd.MarkFlagRequired("version")
+	upgradeCmd.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+}
+
+// getResourceVersions returns the current version of each of the update
+// request's resources, starting with Elasticsearch.
+func getResourceVersions(req *models.DeploymentUpdateRequest) []resourceVersion {
+	var versions []resourceVersion
+	for _, r := range req.Resources.Elasticsearch {
+		if r.Plan.Elasticsearch != nil {
+			versions = append(versions, resourceVersion{"elasticsearch", *r.RefID, r.Plan.Elasticsearch.Version})
+		}
+	}
+	for _, r := range req.Resources.Kibana {
+		if r.Plan.Kibana != nil {
+			versions = append(versions, resourceVersion{"kibana", *r.RefID, r.Plan.Kibana.Version})
+		}
+	}
+	for _, r := range req.Resources.Apm {
+		if r.Plan.Apm != nil {
+			versions = append(versions, resourceVersion{"apm", *r.RefID, r.Plan.Apm.Version})
+		}
+	}
+	for _, r := range req.Resources.IntegrationsServer {
+		if r.Plan.IntegrationsServer != nil {
+			versions = append(versions, resourceVersion{"integrations_server", *r.RefID, r.Plan.IntegrationsServer.Version})
+		}
+	}
+	for _, r := range req.Resources.Appsearch {
+		if r.Plan.Appsearch != nil {
+			versions = append(versions, resourceVersion{"appsearch", *r.RefID, r.Plan.Appsearch.Version})
+		}
+	}
+	for _, r := range req.Resources.EnterpriseSearch {
+		if r.Plan.EnterpriseSearch != nil {
+			versions = append(versions, resourceVersion{"enterprise_search", *r.RefID, r.Plan.EnterpriseSearch.Version})
+		}
+	}
+	return versions
+}
+
+// validateUpgradePath ensures that the deployment can be upgraded from the
+// current to the target version.
+func validateUpgradePath(current, target semver.Version, region string, stacks []*models.StackVersionConfig, resources []resourceVersion) error {
+	var merr = multierror.NewPrefixed("invalid upgrade")
+	if target.LTE(current) {
+		merr = merr.Append(fmt.Errorf("version %s is not higher than the current version %s", target, current))
+	}
+
+	if target.Major > current.Major+1 {
+		merr = merr.Append(fmt.Errorf(
+			"cannot upgrade from %s to %s: upgrade to the latest %d.x version first",
+			current, target, current.Major+1,
+		))
+	}
+
+	var stack *models.StackVersionConfig
+	for _, s := range stacks {
+		if s.Version == target.String() {
+			stack = s
+			break
+		}
+	}
+
+	if stack == nil {
+		merr = merr.Append(fmt.Errorf("version %s is not available in region %s", target, region))
+	} else if stack.MinUpgradableFrom != "" {
+		if min, err := semver.ParseTolerant(stack.MinUpgradableFrom); err == nil && current.LT(min) {
+			merr = merr.Append(fmt.Errorf(
+				"cannot upgrade from %s to %s: the minimum version which can be upgraded is %s",
+				current, target, min,
+			))
+		}
+	}
+
+	for _, r := range resources {
+		for _, u := range unsupportedKinds {
+			if r.kind == u.kind && target.GTE(u.since) {
+				merr = merr.Append(fmt.Errorf(
+					"resource %s [%s] is not supported in version %s and needs to be %s before upgrading",
+					r.kind, r.refID, target, u.action,
+				))
+			}
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
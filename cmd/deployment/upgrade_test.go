@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateUpgradePath(t *testing.T) {
+	stacks := []*models.StackVersionConfig{
+		{Version: "9.0.0", MinUpgradableFrom: "8.18.0"},
+		{Version: "8.15.0", MinUpgradableFrom: "7.17.0"},
+		{Version: "8.14.3", MinUpgradableFrom: "7.17.0"},
+		{Version: "7.17.0", MinUpgradableFrom: "6.8.0"},
+	}
+	esKibana := []resourceVersion{
+		{kind: "elasticsearch", refID: "main-elasticsearch"},
+		{kind: "kibana", refID: "main-kibana"},
+	}
+	tests := []struct {
+		name      string
+		current   string
+		target    string
+		resources []resourceVersion
+		err       string
+	}{
+		{
+			name:      "succeeds on a minor upgrade",
+			current:   "8.14.3",
+			target:    "8.15.0",
+			resources: esKibana,
+		},
+		{
+			name:      "succeeds on a major upgrade from the minimum version",
+			current:   "7.17.0",
+			target:    "8.15.0",
+			resources: esKibana,
+		},
+		{
+			name:      "fails on a downgrade",
+			current:   "8.15.0",
+			target:    "8.14.3",
+			resources: esKibana,
+			err: "invalid upgrade: 1 error occurred:\n" +
+				"\t* version 8.14.3 is not higher than the current version 8.15.0\n\n",
+		},
+		{
+			name:      "fails when the version is not available",
+			current:   "8.14.3",
+			target:    "8.16.0",
+			resources: esKibana,
+			err: "invalid upgrade: 1 error occurred:\n" +
+				"\t* version 8.16.0 is not available in region us-east-1\n\n",
+		},
+		{
+			name:      "fails when skipping a major version",
+			current:   "7.17.0",
+			target:    "9.0.0",
+			resources: esKibana,
+			err: "invalid upgrade: 2 errors occurred:\n" +
+				"\t* cannot upgrade from 7.17.0 to 9.0.0: the minimum version which can be upgraded is 8.18.0\n" +
+				"\t* cannot upgrade from 7.17.0 to 9.0.0: upgrade to the latest 8.x version first\n\n",
+		},
+		{
+			name:    "fails when the deployment contains APM",
+			current: "7.17.0",
+			target:  "8.15.0",
+			resources: append(esKibana,
+				resourceVersion{kind: "apm", refID: "main-apm"},
+			),
+			err: "invalid upgrade: 1 error occurred:\n" +
+				"\t* resource apm [main-apm] is not supported in version 8.15.0 and needs to be migrated to Integrations Server before upgrading\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpgradePath(
+				semver.MustParse(tt.current), semver.MustParse(tt.target),
+				"us-east-1", stacks, tt.resources,
+			)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_getResourceVersions(t *testing.T) {
+	var res models.DeploymentGetResponse
+	require.NoError(t, res.UnmarshalBinary(showApmResp))
+
+	got := getResourceVersions(deploymentapi.NewUpdateRequest(&res))
+	assert.Equal(t, []resourceVersion{
+		{kind: "elasticsearch", refID: "main-elasticsearch", version: "7.8.0"},
+		{kind: "kibana", refID: "main-kibana", version: "7.8.0"},
+		{kind: "apm", refID: "main-apm"},
+	}, got)
+}
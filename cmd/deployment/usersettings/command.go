@@ -0,0 +1,332 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentusersettings
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+var kindNames = map[string]string{
+	util.Elasticsearch: "Elasticsearch",
+	util.Kibana:        "Kibana",
+}
+
+// userSettings are the user settings of a deployment resource.
+type userSettings struct {
+	Kind             string `json:"kind"`
+	RefID            string `json:"ref_id"`
+	Tier             string `json:"tier,omitempty"`
+	UserSettingsYaml string `json:"user_settings_yaml"`
+}
+
+// NewCommand returns the settings command of the specified resource kind,
+// which is either elasticsearch or kibana.
+func NewCommand(kind string) *cobra.Command {
+	var name = kindNames[kind]
+	var command = &cobra.Command{
+		Use:     "settings",
+		Short:   fmt.Sprintf("Manages the user settings of %s resources", name),
+		PreRunE: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	var getCmd = &cobra.Command{
+		Use:     "get <deployment id>",
+		Short:   fmt.Sprintf("Shows the user settings YAML of a %s resource", name),
+		PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := getDeployment(args[0])
+			if err != nil {
+				return err
+			}
+
+			refID, _ := cmd.Flags().GetString("ref-id")
+			tier, _ := cmd.Flags().GetString("tier")
+			settings, err := findUserSettings(deploymentapi.NewUpdateRequest(res), kind, refID, tier)
+			if err != nil {
+				return err
+			}
+
+			return ecctl.Get().Formatter.Format("deployment/usersettings", userSettings{
+				Kind:             kind,
+				RefID:            settings.refID,
+				Tier:             tier,
+				UserSettingsYaml: *settings.yaml,
+			})
+		},
+	}
+
+	var setCmd = &cobra.Command{
+		Use:     "set <deployment id> --file <file.yml>",
+		Short:   fmt.Sprintf("Replaces the user settings YAML of a %s resource", name),
+		Long:    fmt.Sprintf(setLong, name),
+		PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, _ := cmd.Flags().GetString("file")
+			force, _ := cmd.Flags().GetBool("force")
+			if file == "-" && !force {
+				return errors.New("--force is required when the user settings are read from stdin")
+			}
+
+			var contents []byte
+			var err error
+			if file == "-" {
+				contents, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				contents, err = os.ReadFile(file)
+			}
+			if err != nil {
+				return err
+			}
+
+			return updateUserSettings(cmd, args[0], kind, func(string) (string, error) {
+				return string(contents), nil
+			})
+		},
+	}
+
+	var editCmd = &cobra.Command{
+		Use:     "edit <deployment id>",
+		Short:   fmt.Sprintf("Edits the user settings YAML of a %s resource in $EDITOR", name),
+		Long:    fmt.Sprintf(editLong, name),
+		PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateUserSettings(cmd, args[0], kind, func(current string) (string, error) {
+				edited, err := cmdutil.EditContents([]byte(current), kind+"-*.yml")
+				return string(edited), err
+			})
+		},
+	}
+
+	for _, c := range []*cobra.Command{getCmd, setCmd, editCmd} {
+		c.Flags().String("ref-id", "", fmt.Sprintf("Optional ref_id to use for the %s resource, auto-discovered if not specified.", name))
+		if kind == util.Elasticsearch {
+			c.Flags().String("tier", "", "Optional topology element ID whose user settings are used, such as hot_content, instead of the resource's")
+		}
+		command.AddCommand(c)
+	}
+	setCmd.Flags().StringP("file", "f", "", "Required YAML file with the user settings, or - to read them from stdin along with --force")
+	setCmd.MarkFlagRequired("file")
+	setCmd.MarkFlagFilename("file", "yml", "yaml")
+	for _, c := range []*cobra.Command{setCmd, editCmd} {
+		c.Flags().BoolP("track", "t", false, cmdutil.TrackFlagMessage)
+	}
+
+	return command
+}
+
+const setLong = `Replaces the user settings YAML of a %s resource. The YAML is validated locally
+and the changes to the current user settings are shown before asking for
+confirmation, after which a plan which only changes the user settings is applied.
+
+When the user settings are read from stdin with --file -, the confirmation can't
+be read from it, so --force is required.`
+
+const editLong = `Opens the user settings YAML of a %s resource in $VISUAL or $EDITOR. Once the
+editor exits, the YAML is validated locally and the changes are shown before
+asking for confirmation, after which a plan which only changes the user settings
+is applied.`
+
+// updateUserSettings replaces the user settings of the resource with the ones
+// returned by the function, which receives the current settings, and applies
+// the change after showing the diff and asking for confirmation.
+func updateUserSettings(cmd *cobra.Command, id, kind string, newSettings func(current string) (string, error)) error {
+	res, err := getDeployment(id)
+	if err != nil {
+		return err
+	}
+
+	refID, _ := cmd.Flags().GetString("ref-id")
+	tier, _ := cmd.Flags().GetString("tier")
+	req := deploymentapi.NewUpdateRequest(res)
+	settings, err := findUserSettings(req, kind, refID, tier)
+	if err != nil {
+		return err
+	}
+
+	current := *settings.yaml
+	updated, err := newSettings(current)
+	if err != nil {
+		return err
+	}
+
+	if err := validateUserSettings(updated); err != nil {
+		return err
+	}
+
+	diff, err := cmdutil.UnifiedDiff(current, updated, "current", "updated")
+	if err != nil {
+		return err
+	}
+
+	if diff == "" {
+		_, err := fmt.Fprintln(ecctl.Get().Config.OutputDevice, "No changes to the user settings")
+		return err
+	}
+	_, _ = fmt.Fprint(ecctl.Get().Config.OutputDevice, diff)
+
+	force, _ := cmd.Flags().GetBool("force")
+	msg := fmt.Sprintf("Do you want to update the user settings of %s [%s]? [y/n]: ", kind, settings.refID)
+	if !force && !sdkcmdutil.ConfirmAction(msg, os.Stdin, ecctl.Get().Config.OutputDevice) {
+		return nil
+	}
+
+	*settings.yaml = updated
+	updateRes, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		DeploymentID: id,
+		API:          ecctl.Get().API,
+		Request: &models.DeploymentUpdateRequest{
+			Name:         req.Name,
+			PruneOrphans: ec.Bool(false),
+			Resources:    settings.resources,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	track, _ := cmd.Flags().GetBool("track")
+	return cmdutil.Track(cmdutil.NewTrackParams(cmdutil.TrackParamsConfig{
+		App:          ecctl.Get(),
+		DeploymentID: id,
+		Track:        track,
+		Response:     updateRes,
+	}))
+}
+
+func getDeployment(id string) (*models.DeploymentGetResponse, error) {
+	return deploymentapi.Get(deploymentapi.GetParams{
+		API:          ecctl.Get().API,
+		DeploymentID: id,
+		QueryParams: deputil.QueryParams{
+			ShowPlans:      true,
+			ShowSettings:   true,
+			ClearTransient: true,
+		},
+	})
+}
+
+// validateUserSettings ensures that the user settings are a YAML mapping, or
+// empty to remove them.
+func validateUserSettings(s string) error {
+	var settings map[string]interface{}
+	dec := yaml.NewDecoder(bytes.NewBufferString(s))
+	if err := dec.Decode(&settings); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid user settings YAML: %w", err)
+	}
+	return nil
+}
+
+// resourceUserSettings points to the user settings of a resource in an update
+// request.
+type resourceUserSettings struct {
+	refID string
+	yaml  *string
+	// resources only contain the resource.
+	resources *models.DeploymentUpdateResources
+}
+
+// findUserSettings finds the user settings of the resource with the specified
+// ref ID, or of the only resource of the kind when the ref ID is empty. The
+// tier is only supported by Elasticsearch, where it selects the user settings
+// of a topology element instead of the resource's.
+func findUserSettings(req *models.DeploymentUpdateRequest, kind, refID, tier string) (*resourceUserSettings, error) {
+	var refIDs []string
+	var found *resourceUserSettings
+	switch kind {
+	case util.Elasticsearch:
+		for _, r := range req.Resources.Elasticsearch {
+			refIDs = append(refIDs, *r.RefID)
+			if refID != "" && *r.RefID != refID {
+				continue
+			}
+			yml, err := elasticsearchUserSettings(r.Plan, tier)
+			if err != nil {
+				return nil, err
+			}
+			found = &resourceUserSettings{refID: *r.RefID, yaml: yml, resources: &models.DeploymentUpdateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{r},
+			}}
+		}
+	case util.Kibana:
+		for _, r := range req.Resources.Kibana {
+			refIDs = append(refIDs, *r.RefID)
+			if refID != "" && *r.RefID != refID {
+				continue
+			}
+			if r.Plan.Kibana == nil {
+				r.Plan.Kibana = new(models.KibanaConfiguration)
+			}
+			found = &resourceUserSettings{refID: *r.RefID, yaml: &r.Plan.Kibana.UserSettingsYaml, resources: &models.DeploymentUpdateResources{
+				Kibana: []*models.KibanaPayload{r},
+			}}
+		}
+	}
+
+	switch {
+	case refID == "" && len(refIDs) > 1:
+		return nil, fmt.Errorf("deployment has more than one %s resource, please specify --ref-id", kind)
+	case found != nil:
+		return found, nil
+	case refID != "":
+		return nil, fmt.Errorf("deployment has no %s resource with ref id %s", kind, refID)
+	default:
+		return nil, fmt.Errorf("deployment has no %s resources", kind)
+	}
+}
+
+// elasticsearchUserSettings returns the user settings of the Elasticsearch plan,
+// or of the plan's topology element when the tier is specified.
+func elasticsearchUserSettings(plan *models.ElasticsearchClusterPlan, tier string) (*string, error) {
+	if tier == "" {
+		if plan.Elasticsearch == nil {
+			plan.Elasticsearch = new(models.ElasticsearchConfiguration)
+		}
+		return &plan.Elasticsearch.UserSettingsYaml, nil
+	}
+
+	for _, t := range plan.ClusterTopology {
+		if t.ID != tier {
+			continue
+		}
+		if t.Elasticsearch == nil {
+			t.Elasticsearch = new(models.ElasticsearchConfiguration)
+		}
+		return &t.Elasticsearch.UserSettingsYaml, nil
+	}
+
+	return nil, fmt.Errorf("elasticsearch plan has no %s tier", tier)
+}
@@ -0,0 +1,250 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeploymentusersettings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+const testDeploymentID = "320b7b540dfc967a7a649c18e2fce4ed"
+
+func newUserSettingsTestDeployment() *models.DeploymentGetResponse {
+	return &models.DeploymentGetResponse{
+		ID:   ec.String(testDeploymentID),
+		Name: ec.String("my-deployment"),
+		Resources: &models.DeploymentResources{
+			Elasticsearch: []*models.ElasticsearchResourceInfo{{
+				ID:     ec.String("3531aaf988594efa87c1aabb7caed337"),
+				RefID:  ec.String("main-elasticsearch"),
+				Region: ec.String("us-east-1"),
+				Info: &models.ElasticsearchClusterInfo{
+					ClusterName: ec.String("my-deployment"),
+					PlanInfo: &models.ElasticsearchClusterPlansInfo{
+						Current: &models.ElasticsearchClusterPlanInfo{
+							Plan: &models.ElasticsearchClusterPlan{
+								Elasticsearch: &models.ElasticsearchConfiguration{
+									Version:          "8.13.2",
+									UserSettingsYaml: "action.auto_create_index: false\n",
+								},
+								ClusterTopology: []*models.ElasticsearchClusterTopologyElement{{
+									ID: "hot_content",
+									Elasticsearch: &models.ElasticsearchConfiguration{
+										UserSettingsYaml: "indices.recovery.max_bytes_per_sec: 100mb\n",
+									},
+								}},
+							},
+						},
+					},
+				},
+			}},
+			Kibana: []*models.KibanaResourceInfo{{
+				ID:                        ec.String("46fa4c8a5a36a58e6da2c0ff9e5bc3cd"),
+				RefID:                     ec.String("main-kibana"),
+				Region:                    ec.String("us-east-1"),
+				ElasticsearchClusterRefID: ec.String("main-elasticsearch"),
+				Info: &models.KibanaClusterInfo{
+					ClusterName: ec.String("my-deployment"),
+					PlanInfo: &models.KibanaClusterPlansInfo{
+						Current: &models.KibanaClusterPlanInfo{
+							Plan: &models.KibanaClusterPlan{
+								Kibana: &models.KibanaConfiguration{Version: "8.13.2"},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+// newSettingsCommand returns the settings command of the kind with the root
+// command's persistent force flag.
+func newSettingsCommand(kind string) *cobra.Command {
+	cmd := NewCommand(kind)
+	cmd.PersistentFlags().Bool("force", false, "")
+	return cmd
+}
+
+func subcommand(cmd *cobra.Command, name string) *cobra.Command {
+	c, _, _ := cmd.Find([]string{name})
+	return c
+}
+
+func TestNewCommand(t *testing.T) {
+	var dir = t.TempDir()
+	var settingsFile = filepath.Join(dir, "settings.yml")
+	require.NoError(t, os.WriteFile(settingsFile, []byte("server.publicBaseUrl: https://kibana.example.com\n"), 0600))
+	var invalidFile = filepath.Join(dir, "invalid.yml")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("server: [unclosed\n"), 0600))
+
+	var es, kibana = newSettingsCommand(util.Elasticsearch), newSettingsCommand(util.Kibana)
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "shows the elasticsearch user settings",
+			args: testutils.Args{
+				Cmd:  subcommand(es, "get"),
+				Args: []string{"get", testDeploymentID},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "text",
+					Responses:    []mock.Response{mock.New200StructResponse(newUserSettingsTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{Stdout: "action.auto_create_index: false\n"},
+		},
+		{
+			name: "shows the elasticsearch tier user settings",
+			args: testutils.Args{
+				Cmd:  subcommand(es, "get"),
+				Args: []string{"get", testDeploymentID, "--tier", "hot_content"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newUserSettingsTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "{\n  \"kind\": \"elasticsearch\",\n  \"ref_id\": \"main-elasticsearch\",\n  \"tier\": \"hot_content\",\n" +
+					"  \"user_settings_yaml\": \"indices.recovery.max_bytes_per_sec: 100mb\\n\"\n}\n",
+			},
+		},
+		{
+			name: "fails when the tier doesn't exist",
+			args: testutils.Args{
+				Cmd:  subcommand(es, "get"),
+				Args: []string{"get", testDeploymentID, "--tier", "warm"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newUserSettingsTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{Err: "elasticsearch plan has no warm tier"},
+		},
+		{
+			name: "fails when the YAML is invalid",
+			args: testutils.Args{
+				Cmd:  subcommand(kibana, "set"),
+				Args: []string{"set", testDeploymentID, "--file", invalidFile},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newUserSettingsTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{
+				Err: "invalid user settings YAML: yaml: line 1: did not find expected ',' or ']'",
+			},
+		},
+		{
+			name: "fails when the YAML is read from stdin without --force",
+			args: testutils.Args{
+				Cmd:  subcommand(kibana, "set"),
+				Args: []string{"set", testDeploymentID, "--file", "-"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{mock.SampleInternalError()}},
+			},
+			want: testutils.Assertion{Err: "--force is required when the user settings are read from stdin"},
+		},
+		{
+			name: "shows the diff and updates only the kibana user settings",
+			args: testutils.Args{
+				Cmd:  subcommand(kibana, "set"),
+				Args: []string{"set", testDeploymentID, "--file", settingsFile, "--force"},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{
+						mock.New200StructResponse(newUserSettingsTestDeployment()),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/" + testDeploymentID,
+								Host:   api.DefaultMockHost,
+								Query: map[string][]string{
+									"hide_pruned_orphans": {"false"},
+									"skip_snapshot":       {"false"},
+									"validate_only":       {"false"},
+								},
+								Body: mock.NewStringBody(`{"name":"my-deployment","prune_orphans":false,"resources":{"apm":null,"appsearch":null,` +
+									`"elasticsearch":null,"enterprise_search":null,"integrations_server":null,"kibana":[{"display_name":"my-deployment",` +
+									`"elasticsearch_cluster_ref_id":"main-elasticsearch","plan":{"cluster_topology":[],"kibana":{` +
+									`"user_settings_yaml":"server.publicBaseUrl: https://kibana.example.com\n","version":"8.13.2"}},` +
+									`"ref_id":"main-kibana","region":"us-east-1"}]}}` + "\n"),
+							},
+							mock.NewStringBody(`{"id":"`+testDeploymentID+`"}`),
+						),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: "--- current\n+++ updated\n@@ -0,0 +1 @@\n+server.publicBaseUrl: https://kibana.example.com\n" +
+					"{\n  \"id\": \"" + testDeploymentID + "\",\n  \"name\": null,\n  \"resources\": null\n}\n",
+			},
+		},
+		{
+			name: "doesn't update the settings when they haven't changed",
+			args: testutils.Args{
+				Cmd:  subcommand(kibana, "set"),
+				Args: []string{"set", testDeploymentID, "--file", os.DevNull},
+				Cfg: testutils.MockCfg{
+					Responses: []mock.Response{mock.New200StructResponse(newUserSettingsTestDeployment())},
+				},
+			},
+			want: testutils.Assertion{Stdout: "No changes to the user settings\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+		})
+	}
+}
+
+func Test_findUserSettings(t *testing.T) {
+	var res = newUserSettingsTestDeployment()
+	res.Resources.Kibana = append(res.Resources.Kibana, res.Resources.Kibana[0])
+
+	_, err := findUserSettings(&models.DeploymentUpdateRequest{Resources: &models.DeploymentUpdateResources{}}, util.Kibana, "", "")
+	assert.EqualError(t, err, "deployment has no kibana resources")
+
+	req := &models.DeploymentUpdateRequest{Resources: &models.DeploymentUpdateResources{
+		Kibana: []*models.KibanaPayload{
+			{RefID: ec.String("kibana-1"), Plan: &models.KibanaClusterPlan{}},
+			{RefID: ec.String("kibana-2"), Plan: &models.KibanaClusterPlan{}},
+		},
+	}}
+	_, err = findUserSettings(req, util.Kibana, "", "")
+	assert.EqualError(t, err, "deployment has more than one kibana resource, please specify --ref-id")
+
+	_, err = findUserSettings(req, util.Kibana, "kibana-3", "")
+	assert.EqualError(t, err, "deployment has no kibana resource with ref id kibana-3")
+
+	settings, err := findUserSettings(req, util.Kibana, "kibana-2", "")
+	require.NoError(t, err)
+	assert.Equal(t, "kibana-2", settings.refID)
+	assert.Equal(t, []*models.KibanaPayload{req.Resources.Kibana[1]}, settings.resources.Kibana)
+}
@@ -0,0 +1,336 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	sdkcmdutil "github.com/elastic/cloud-sdk-go/pkg/util/cmdutil"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/ecctl"
+)
+
+const waitLong = `Waits until the deployment meets the specified condition, polling the deployment
+with the configured --poll-frequency. Unlike --track, it doesn't need to be tied to
+a plan change, so it can be used by scripts which didn't initiate the change.
+
+The following conditions are supported:
+
+  healthy                The deployment and all its resources are healthy and none
+                         of them has a pending plan.
+  plan-complete          None of the deployment resources has a pending plan.
+  version=<version>      All the resources run the version, "x" can be used as a
+                         wildcard, such as version=8.x or version=8.15.x.
+  stopped                All the deployment resources are stopped.
+  resource=<kind>:<status>
+                         The resources of the kind have the status, such as
+                         resource=kibana:started.
+
+When the condition isn't met before the --timeout expires, the command exits with
+the code 124.`
+
+const waitExample = `## Wait up to 30 minutes for a deployment to be healthy.
+$ ecctl deployment wait 5c17ad7c8df73206baa54b6e2829d9bc --for healthy --timeout 30m
+Waiting for deployment 5c17ad7c8df73206baa54b6e2829d9bc to be healthy: elasticsearch [main-elasticsearch] plan pending
+Deployment 5c17ad7c8df73206baa54b6e2829d9bc is healthy
+
+## Wait for all the deployment resources to run any 8.x version.
+$ ecctl deployment wait 5c17ad7c8df73206baa54b6e2829d9bc --for version=8.x`
+
+// waitTimeoutReturnCode is the exit code used when the wait times out,
+// matching the one used by the timeout(1) utility.
+const waitTimeoutReturnCode = 124
+
+// waitCondition is a condition a deployment can be waited for.
+type waitCondition struct {
+	// description of the condition, used in the output messages.
+	description string
+
+	// check returns true when the deployment meets the condition, and a
+	// description of the deployment's current state.
+	check func(res *models.DeploymentGetResponse) (bool, string)
+}
+
+// waitTimeoutError is returned when a wait condition isn't met before the
+// timeout expires.
+type waitTimeoutError struct {
+	id          string
+	description string
+	timeout     time.Duration
+}
+
+func (e waitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for deployment %s to be %s",
+		e.timeout, e.id, e.description,
+	)
+}
+
+// ReturnCode returns the exit code used when the wait times out.
+func (e waitTimeoutError) ReturnCode() int { return waitTimeoutReturnCode }
+
+var waitCmd = &cobra.Command{
+	Use:     "wait <deployment id> --for <condition>",
+	Short:   "Waits until the deployment meets the specified condition",
+	Long:    waitLong,
+	Example: waitExample,
+	PreRunE: sdkcmdutil.MinimumNArgsAndUUID(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		forCondition, _ := cmd.Flags().GetString("for")
+		condition, err := parseWaitCondition(forCondition)
+		if err != nil {
+			return err
+		}
+
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		maxRetries, pollFrequency := cmdutil.GetTrackSettings(cmd)
+		if err := waitForDeployment(waitParams{
+			ID:            args[0],
+			Condition:     condition,
+			Timeout:       timeout,
+			PollFrequency: pollFrequency,
+			MaxRetries:    maxRetries,
+			Writer:        cmd.ErrOrStderr(),
+		}); err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(ecctl.Get().Config.OutputDevice, "Deployment %s is %s\n",
+			args[0], condition.description,
+		)
+		return err
+	},
+}
+
+func init() {
+	initWaitFlags()
+}
+
+func initWaitFlags() {
+	Command.AddCommand(waitCmd)
+	waitCmd.Flags().String("for", "", "Required condition to wait for (healthy, plan-complete, version=<version>, stopped, resource=<kind>:<status>)")
+	waitCmd.MarkFlagRequired("for")
+	waitCmd.Flags().Duration("timeout", 30*time.Minute, "Maximum time to wait for the condition to be met")
+	cmdutil.AddTrackFlags(waitCmd)
+}
+
+// waitParams is consumed by waitForDeployment.
+type waitParams struct {
+	ID            string
+	Condition     waitCondition
+	Timeout       time.Duration
+	PollFrequency time.Duration
+	MaxRetries    int
+	Writer        io.Writer
+}
+
+// waitForDeployment polls the deployment until it meets the condition, the
+// timeout expires or more than MaxRetries consecutive API calls fail. Every
+// time the deployment's state changes, it's written to the Writer.
+func waitForDeployment(params waitParams) error {
+	var deadline = time.Now().Add(params.Timeout)
+	var lastState string
+	var failures int
+	for {
+		res, err := deploymentapi.Get(deploymentapi.GetParams{
+			API:          ecctl.Get().API,
+			DeploymentID: params.ID,
+			QueryParams: deputil.QueryParams{
+				ShowPlans:       true,
+				ShowPlanHistory: true,
+			},
+		})
+		if err != nil {
+			failures++
+			if failures > params.MaxRetries {
+				return err
+			}
+		} else {
+			failures = 0
+			met, state := params.Condition.check(res)
+			if met {
+				return nil
+			}
+			if state != lastState {
+				_, _ = fmt.Fprintf(params.Writer, "Waiting for deployment %s to be %s: %s\n",
+					params.ID, params.Condition.description, state,
+				)
+				lastState = state
+			}
+		}
+
+		if time.Now().Add(params.PollFrequency).After(deadline) {
+			return waitTimeoutError{
+				id: params.ID, description: params.Condition.description, timeout: params.Timeout,
+			}
+		}
+		time.Sleep(params.PollFrequency)
+	}
+}
+
+// parseWaitCondition parses the --for flag value into a waitCondition.
+func parseWaitCondition(s string) (waitCondition, error) {
+	name, value := s, ""
+	if i := strings.Index(s, "="); i >= 0 {
+		name, value = s[:i], s[i+1:]
+	}
+
+	switch name {
+	case "healthy":
+		return waitCondition{description: "healthy", check: checkHealthy}, nil
+	case "plan-complete":
+		return waitCondition{description: "plan-complete", check: checkPlanComplete}, nil
+	case "stopped":
+		return waitCondition{description: "stopped", check: newResourceStatusCheck("", "stopped")}, nil
+	case "version":
+		if value == "" {
+			return waitCondition{}, fmt.Errorf(`invalid wait condition "%s": a version must be specified`, s)
+		}
+		return waitCondition{description: "running version " + value, check: newVersionCheck(value)}, nil
+	case "resource":
+		kind, status := value, ""
+		if i := strings.Index(value, ":"); i >= 0 {
+			kind, status = value[:i], value[i+1:]
+		}
+		if !containsString(listKinds, kind) || status == "" {
+			return waitCondition{}, fmt.Errorf(
+				`invalid wait condition "%s": must be in the resource=<kind>:<status> format, where kind is one of %s`,
+				s, strings.Join(listKinds, ", "),
+			)
+		}
+		return waitCondition{
+			description: fmt.Sprintf("%s %s", kind, status),
+			check:       newResourceStatusCheck(kind, status),
+		}, nil
+	}
+
+	return waitCondition{}, fmt.Errorf(
+		`invalid wait condition "%s": must be one of healthy, plan-complete, version=<version>, stopped or resource=<kind>:<status>`, s,
+	)
+}
+
+// checkHealthy checks that the deployment and all its resources are healthy
+// and none of them has a pending plan. Unlike the health command, a failed last
+// plan attempt doesn't prevent the condition from being met, since the failed
+// plan may have been rolled back.
+func checkHealthy(res *models.DeploymentGetResponse) (bool, string) {
+	var states []string
+	if res.Healthy == nil || !*res.Healthy {
+		states = append(states, "deployment unhealthy")
+	}
+	for _, r := range getResourcesHealth(res.Resources) {
+		switch {
+		case r.Plan == planStatePending:
+			states = append(states, fmt.Sprintf("%s [%s] plan pending", r.Kind, r.RefID))
+		case !r.Healthy:
+			states = append(states, fmt.Sprintf("%s [%s] unhealthy", r.Kind, r.RefID))
+		}
+	}
+	if len(states) > 0 {
+		return false, strings.Join(states, ", ")
+	}
+	return true, healthStatusHealthy
+}
+
+// checkPlanComplete checks that none of the deployment resources has a
+// pending plan.
+func checkPlanComplete(res *models.DeploymentGetResponse) (bool, string) {
+	var pending []string
+	for _, r := range getResourcesHealth(res.Resources) {
+		if r.Plan == planStatePending {
+			pending = append(pending, fmt.Sprintf("%s [%s]", r.Kind, r.RefID))
+		}
+	}
+	if len(pending) > 0 {
+		return false, "pending plans on " + strings.Join(pending, ", ")
+	}
+	return true, ""
+}
+
+// newResourceStatusCheck returns a check which ensures that all the resources
+// of the specified kind have the status. When no kind is specified, all the
+// deployment resources are checked.
+func newResourceStatusCheck(kind, status string) func(*models.DeploymentGetResponse) (bool, string) {
+	return func(res *models.DeploymentGetResponse) (bool, string) {
+		var states []string
+		var met = true
+		for _, r := range getResourcesHealth(res.Resources) {
+			if kind != "" && r.Kind != kind {
+				continue
+			}
+			states = append(states, fmt.Sprintf("%s [%s] %s", r.Kind, r.RefID, r.Status))
+			if r.Status != status {
+				met = false
+			}
+		}
+		if len(states) == 0 {
+			return false, "no matching resources found"
+		}
+		return met, strings.Join(states, ", ")
+	}
+}
+
+// newVersionCheck returns a check which ensures that all the resources run a
+// version which matches the pattern and none of them has a pending plan.
+func newVersionCheck(pattern string) func(*models.DeploymentGetResponse) (bool, string) {
+	return func(res *models.DeploymentGetResponse) (bool, string) {
+		if ok, state := checkPlanComplete(res); !ok {
+			return false, state
+		}
+
+		var states []string
+		var met = true
+		for _, r := range getResourcesHealth(res.Resources) {
+			if r.Version == "" {
+				continue
+			}
+			states = append(states, fmt.Sprintf("%s [%s] %s", r.Kind, r.RefID, r.Version))
+			if !matchVersion(pattern, r.Version) {
+				met = false
+			}
+		}
+		if len(states) == 0 {
+			return false, "no resource versions found"
+		}
+		return met, strings.Join(states, ", ")
+	}
+}
+
+// matchVersion returns true when the version matches the pattern. Each of the
+// pattern's dot separated components must either be equal to the version's or
+// be "x", which matches any value. Any version components not present in the
+// pattern are ignored.
+func matchVersion(pattern, version string) bool {
+	var want, got = strings.Split(pattern, "."), strings.Split(version, ".")
+	if len(want) > len(got) {
+		return false
+	}
+	for i, w := range want {
+		if w != "x" && w != got[i] {
+			return false
+		}
+	}
+	return true
+}
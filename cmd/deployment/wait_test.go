@@ -0,0 +1,258 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmddeployment
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+)
+
+func Test_matchVersion(t *testing.T) {
+	tests := []struct {
+		pattern string
+		version string
+		want    bool
+	}{
+		{pattern: "8.15.0", version: "8.15.0", want: true},
+		{pattern: "8.x", version: "8.15.0", want: true},
+		{pattern: "8.15.x", version: "8.15.3", want: true},
+		{pattern: "8", version: "8.15.3", want: true},
+		{pattern: "8.x", version: "7.17.0"},
+		{pattern: "8.15.0", version: "8.15.1"},
+		{pattern: "8.15.0.1", version: "8.15.0"},
+		{pattern: "8.x", version: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchVersion(tt.pattern, tt.version))
+		})
+	}
+}
+
+func Test_parseWaitCondition(t *testing.T) {
+	stopped := newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+		Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+	}, true, false)
+	stopped.Resources.Elasticsearch[0].Info.Status = ec.String("stopped")
+
+	tests := []struct {
+		name      string
+		condition string
+		res       *models.DeploymentGetResponse
+		want      bool
+		state     string
+		err       string
+	}{
+		{
+			name:      "healthy",
+			condition: "healthy",
+			res:       stopped,
+			want:      true,
+			state:     "healthy",
+		},
+		{
+			name:      "healthy after a failed plan has been rolled back",
+			condition: "healthy",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+				History: []*models.ElasticsearchClusterPlanInfo{
+					{Healthy: ec.Bool(false), PlanAttemptLog: []*models.ClusterPlanStepInfo{{
+						StepID: ec.String("rolling-upgrade"), Status: ec.String("error"),
+					}}},
+				},
+			}, true, false),
+			want:  true,
+			state: "healthy",
+		},
+		{
+			name:      "healthy is not met with an unhealthy resource",
+			condition: "healthy",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{Healthy: ec.Bool(true)},
+			}, false, false),
+			state: "deployment unhealthy, kibana [main-kibana] unhealthy",
+		},
+		{
+			name:      "healthy is not met with a pending plan",
+			condition: "healthy",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Pending: &models.ElasticsearchClusterPlanInfo{},
+			}, true, false),
+			state: "elasticsearch [main-elasticsearch] plan pending",
+		},
+		{
+			name:      "stopped is not met when a resource is started",
+			condition: "stopped",
+			res:       stopped,
+			state:     "elasticsearch [main-elasticsearch] stopped, kibana [main-kibana] started",
+		},
+		{
+			name:      "resource status",
+			condition: "resource=elasticsearch:stopped",
+			res:       stopped,
+			want:      true,
+			state:     "elasticsearch [main-elasticsearch] stopped",
+		},
+		{
+			name:      "resource status with no matching resources",
+			condition: "resource=apm:started",
+			res:       stopped,
+			state:     "no matching resources found",
+		},
+		{
+			name:      "plan-complete is not met with a pending plan",
+			condition: "plan-complete",
+			res: newHealthTestDeployment(&models.ElasticsearchClusterPlansInfo{
+				Pending: &models.ElasticsearchClusterPlanInfo{},
+			}, true, false),
+			state: "pending plans on elasticsearch [main-elasticsearch]",
+		},
+		{
+			name:      "fails on an unknown condition",
+			condition: "ready",
+			err:       `invalid wait condition "ready": must be one of healthy, plan-complete, version=<version>, stopped or resource=<kind>:<status>`,
+		},
+		{
+			name:      "fails on a version condition without a version",
+			condition: "version=",
+			err:       `invalid wait condition "version=": a version must be specified`,
+		},
+		{
+			name:      "fails on a resource condition without a status",
+			condition: "resource=kibana",
+			err:       `invalid wait condition "resource=kibana": must be in the resource=<kind>:<status> format, where kind is one of elasticsearch, kibana, apm, integrations_server, enterprise_search, appsearch`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := parseWaitCondition(tt.condition)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			got, state := condition.check(tt.res)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.state, state)
+		})
+	}
+}
+
+func Test_waitCmd(t *testing.T) {
+	const id = "5c17ad7c8df73206baa54b6e2829d9bc"
+	newGetResponse := func(version string, pending bool) mock.Response {
+		plans := &models.ElasticsearchClusterPlansInfo{
+			Current: &models.ElasticsearchClusterPlanInfo{
+				Healthy: ec.Bool(true),
+				Plan: &models.ElasticsearchClusterPlan{
+					Elasticsearch: &models.ElasticsearchConfiguration{Version: version},
+				},
+			},
+		}
+		if pending {
+			plans.Pending = &models.ElasticsearchClusterPlanInfo{}
+		}
+		res := newHealthTestDeployment(plans, true, false)
+		res.Resources.Kibana = nil
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Method: "GET",
+				Path:   "/api/v1/deployments/" + id,
+				Host:   api.DefaultMockHost,
+				Query: url.Values{
+					"convert_legacy_plans": {"false"},
+					"show_metadata":        {"false"},
+					"show_plan_defaults":   {"false"},
+					"show_plan_history":    {"true"},
+					"show_plan_logs":       {"false"},
+					"show_plans":           {"true"},
+					"show_settings":        {"false"},
+					"show_system_alerts":   {"5"},
+				},
+			},
+			mock.NewStructBody(res),
+		)
+	}
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "fails on an invalid condition",
+			args: testutils.Args{
+				Cmd:  waitCmd,
+				Args: []string{"wait", id, "--for", "ready"},
+			},
+			want: testutils.Assertion{
+				Err: `invalid wait condition "ready": must be one of healthy, plan-complete, version=<version>, stopped or resource=<kind>:<status>`,
+			},
+		},
+		{
+			name: "waits until the deployment runs the version",
+			args: testutils.Args{
+				Cmd:  waitCmd,
+				Args: []string{"wait", id, "--for", "version=8.x", "--poll-frequency", "1ms"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newGetResponse("7.17.0", true),
+					newGetResponse("8.15.0", true),
+					newGetResponse("8.15.0", false),
+				}},
+			},
+			want: testutils.Assertion{
+				Stdout: "Deployment 5c17ad7c8df73206baa54b6e2829d9bc is running version 8.x\n",
+				Stderr: "Waiting for deployment 5c17ad7c8df73206baa54b6e2829d9bc to be running version 8.x: pending plans on elasticsearch [main-elasticsearch]\n",
+			},
+		},
+		{
+			name: "times out when the condition isn't met",
+			args: testutils.Args{
+				Cmd:  waitCmd,
+				Args: []string{"wait", id, "--for", "version=8.x", "--poll-frequency", "1ms", "--timeout", "1ms"},
+				Cfg: testutils.MockCfg{Responses: []mock.Response{
+					newGetResponse("7.17.0", false),
+				}},
+			},
+			want: testutils.Assertion{
+				Err:    "timed out after 1ms waiting for deployment 5c17ad7c8df73206baa54b6e2829d9bc to be running version 8.x",
+				Stderr: "Waiting for deployment 5c17ad7c8df73206baa54b6e2829d9bc to be running version 8.x: elasticsearch [main-elasticsearch] 7.17.0\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initWaitFlags()
+		})
+	}
+}
+
+func Test_waitTimeoutError(t *testing.T) {
+	assert.Equal(t, 124, waitTimeoutError{}.ReturnCode())
+}
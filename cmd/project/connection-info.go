@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdproject
+
+import (
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/elastic/ecctl/cmd/util"
+	"github.com/elastic/ecctl/pkg/connectioninfo"
+	"github.com/elastic/ecctl/pkg/ecctl"
+	"github.com/elastic/ecctl/pkg/project"
+)
+
+const connectionInfoExample = `## Write the project endpoints and cloud ID to a .env file.
+$ ecctl project connection-info 1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d > .env
+
+## Create a Kubernetes ConfigMap with the project endpoints and cloud ID.
+$ ecctl project connection-info 1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d --as configmap | kubectl apply -f -`
+
+var connectionInfoCmd = &cobra.Command{
+	Use:     "connection-info <project-id>",
+	Short:   "Prints the serverless project endpoints, ports and cloud ID in application friendly formats",
+	Example: connectionInfoExample,
+	PreRunE: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectType, _ := cmd.Flags().GetString("type")
+
+		res, err := project.Show(project.ShowParams{
+			API:    ecctl.Get().API,
+			Host:   ecctl.Get().Config.Host,
+			ID:     args[0],
+			Type:   projectType,
+			Client: ecctl.Get().Config.Client,
+		})
+		if err != nil {
+			return err
+		}
+
+		info, err := connectioninfo.NewFromProject(res)
+		if err != nil {
+			return err
+		}
+
+		return info.Write(ecctl.Get().Config.OutputDevice, cmdutil.GetConnectionInfoWriteParams(cmd))
+	},
+}
+
+func init() {
+	Command.AddCommand(connectionInfoCmd)
+	initConnectionInfoFlags()
+}
+
+func initConnectionInfoFlags() {
+	connectionInfoCmd.Flags().String("type", "", "Project type (elasticsearch/search, observability, security). Auto-detected if omitted.")
+	cmdutil.AddConnectionInfoFlags(connectionInfoCmd)
+}
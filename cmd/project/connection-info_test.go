@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdproject
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+
+	"github.com/elastic/ecctl/cmd/util/testutils"
+	"github.com/elastic/ecctl/pkg/project"
+)
+
+func Test_connectionInfoCmd(t *testing.T) {
+	var proj = project.Project{
+		ID:      "abc123",
+		Name:    "My Project",
+		Type:    "elasticsearch",
+		CloudID: "my-project:abc==",
+		Endpoints: map[string]string{
+			"kibana":        "https://abc123.kb.us-east-1.aws.elastic.cloud",
+			"elasticsearch": "https://abc123.es.us-east-1.aws.elastic.cloud",
+		},
+	}
+
+	tests := []struct {
+		name string
+		args testutils.Args
+		want testutils.Assertion
+	}{
+		{
+			name: "prints the connection info in the .env format",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", "abc123", "--type", "elasticsearch"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newProjectShowBody(proj)}},
+			},
+			want: testutils.Assertion{
+				Stdout: "ELASTIC_CLOUD_ID='my-project:abc=='\n" +
+					"ELASTICSEARCH_URL='https://abc123.es.us-east-1.aws.elastic.cloud'\n" +
+					"ELASTICSEARCH_HOST='abc123.es.us-east-1.aws.elastic.cloud'\n" +
+					"ELASTICSEARCH_PORT='443'\n" +
+					"KIBANA_URL='https://abc123.kb.us-east-1.aws.elastic.cloud'\n" +
+					"KIBANA_HOST='abc123.kb.us-east-1.aws.elastic.cloud'\n" +
+					"KIBANA_PORT='443'\n",
+			},
+		},
+		{
+			name: "prints the connection info as a Kubernetes ConfigMap",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", "abc123", "--type", "elasticsearch", "--as", "configmap"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newProjectShowBody(proj)}},
+			},
+			want: testutils.Assertion{
+				Stdout: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-project\ndata:\n" +
+					"  ELASTIC_CLOUD_ID: my-project:abc==\n" +
+					"  ELASTICSEARCH_HOST: abc123.es.us-east-1.aws.elastic.cloud\n" +
+					`  ELASTICSEARCH_PORT: "443"` + "\n" +
+					"  ELASTICSEARCH_URL: https://abc123.es.us-east-1.aws.elastic.cloud\n" +
+					"  KIBANA_HOST: abc123.kb.us-east-1.aws.elastic.cloud\n" +
+					`  KIBANA_PORT: "443"` + "\n" +
+					"  KIBANA_URL: https://abc123.kb.us-east-1.aws.elastic.cloud\n",
+			},
+		},
+		{
+			name: "fails with an invalid format",
+			args: testutils.Args{
+				Cmd:  connectionInfoCmd,
+				Args: []string{"connection-info", "abc123", "--type", "elasticsearch", "--as", "xml"},
+				Cfg:  testutils.MockCfg{Responses: []mock.Response{newProjectShowBody(proj)}},
+			},
+			want: testutils.Assertion{
+				Err: `invalid connection info format "xml", must be one of [env|dotenv|json|secret|configmap]`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunCmdAssertion(t, tt.args, tt.want)
+			tt.args.Cmd.ResetFlags()
+			defer initConnectionInfoFlags()
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package cmdproject
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -26,19 +27,45 @@ import (
 	"github.com/elastic/ecctl/pkg/project"
 )
 
+const listLong = `Lists serverless projects of all types, or of the type specified with --type.
+
+When --output ndjson is used, each project is written on its own line as soon as
+its page of results is received and the progress is reported on stderr.`
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "Lists serverless projects",
+	Long:    listLong,
 	PreRunE: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectType, _ := cmd.Flags().GetString("type")
+		maxResults, _ := cmd.Flags().GetInt("max-results")
+
+		params := project.ListParams{
+			API:        ecctl.Get().API,
+			Host:       ecctl.Get().Config.Host,
+			Type:       projectType,
+			Client:     ecctl.Get().Config.Client,
+			MaxResults: maxResults,
+		}
+
+		if ecctl.Get().Config.Output == ecctl.NDJSONOutput {
+			var count int
+			params.OnPage = func(pt project.ProjectType, projects []project.Project) error {
+				for _, p := range projects {
+					if err := ecctl.Get().Formatter.Format("", p); err != nil {
+						return err
+					}
+				}
+				count += len(projects)
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Fetched %d %s projects, %d in total\n", len(projects), pt, count)
+				return nil
+			}
+			_, err := project.List(params)
+			return err
+		}
 
-		res, err := project.List(project.ListParams{
-			API:    ecctl.Get().API,
-			Host:   ecctl.Get().Config.Host,
-			Type:   projectType,
-			Client: ecctl.Get().Config.Client,
-		})
+		res, err := project.List(params)
 		if err != nil {
 			return err
 		}
@@ -51,4 +78,5 @@ func init() {
 	Command.AddCommand(listCmd)
 
 	listCmd.Flags().String("type", "", "Filters by project type (elasticsearch/search, observability, security)")
+	listCmd.Flags().Int("max-results", 0, "Maximum number of projects to return, 0 returns all the projects")
 }
@@ -33,9 +33,16 @@ func newProjectListBody(projects []project.Project) mock.Response {
 	return mock.New200Response(mock.NewByteBody(b))
 }
 
+func newProjectListPageBody(projects []project.Project, nextPage string) mock.Response {
+	body := project.ListResponse{Items: projects, NextPage: nextPage}
+	b, _ := json.Marshal(body)
+	return mock.New200Response(mock.NewByteBody(b))
+}
+
 func initListFlags() {
 	listCmd.ResetFlags()
 	listCmd.Flags().String("type", "", "Filters by project type (elasticsearch, observability, security)")
+	listCmd.Flags().Int("max-results", 0, "Maximum number of projects to return, 0 returns all the projects")
 }
 
 func Test_listCmd(t *testing.T) {
@@ -77,6 +84,9 @@ func Test_listCmd(t *testing.T) {
 	var esResult = project.ListResult{Projects: esProjects}
 	esResultJSON, _ := json.MarshalIndent(esResult, "", "  ")
 
+	var esRepeatedResult = project.ListResult{Projects: append(esProjects, esProjects...)}
+	esRepeatedResultJSON, _ := json.MarshalIndent(esRepeatedResult, "", "  ")
+
 	tests := []struct {
 		name string
 		args testutils.Args
@@ -199,6 +209,59 @@ func Test_listCmd(t *testing.T) {
 `,
 			},
 		},
+		{
+			name: "stops listing when the API returns the same next page cursor",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", "--type", "elasticsearch"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "json",
+					Responses: []mock.Response{
+						newProjectListPageBody(esProjects, "cursor-1"),
+						newProjectListPageBody(esProjects, "cursor-1"),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: string(esRepeatedResultJSON) + "\n",
+			},
+		},
+		{
+			name: "stops listing when the API returns an empty page",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", "--type", "elasticsearch"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "json",
+					Responses: []mock.Response{
+						newProjectListPageBody(esProjects, "cursor-1"),
+						newProjectListPageBody(nil, "cursor-2"),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: string(esResultJSON) + "\n",
+			},
+		},
+		{
+			name: "streams all project types as ndjson up to the maximum number of results",
+			args: testutils.Args{
+				Cmd:  listCmd,
+				Args: []string{"list", "--max-results", "2"},
+				Cfg: testutils.MockCfg{
+					OutputFormat: "ndjson",
+					Responses: []mock.Response{
+						newProjectListBody(esProjects),
+						newProjectListBody(append(obsProjects, obsProjects...)),
+					},
+				},
+			},
+			want: testutils.Assertion{
+				Stdout: `{"id":"abc123","name":"my-es-project","alias":"my-es-project-abc123","type":"elasticsearch","region_id":"aws-us-east-1","metadata":{}}` + "\n" +
+					`{"id":"def456","name":"my-obs-project","alias":"my-obs-project-def456","type":"observability","region_id":"gcp-us-central1","metadata":{}}` + "\n",
+				Stderr: "Fetched 1 elasticsearch projects, 1 in total\nFetched 1 observability projects, 2 in total\n",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
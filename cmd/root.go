@@ -114,7 +114,8 @@ func init() {
 	RootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose mode")
 	RootCmd.PersistentFlags().Bool("verbose-credentials", false, "When set, Authorization headers on the request/response trail will be displayed as plain text")
 	RootCmd.PersistentFlags().String("verbose-file", "", "When set, the verbose request/response trail will be written to the defined file")
-	RootCmd.PersistentFlags().String("output", "text", "Output format [text|json]")
+	RootCmd.PersistentFlags().String("output", "text", "Output format [text|json|ndjson]")
+	RootCmd.PersistentFlags().String("track-format", "text", "Plan tracking format [text|ndjson], always ndjson when the output is json or ndjson")
 	RootCmd.PersistentFlags().Bool("force", false, "Do not ask for confirmation")
 	RootCmd.PersistentFlags().String("message", "", "A message to set on cluster operation")
 	RootCmd.PersistentFlags().String("format", "", "Formats the output using a Go template")
@@ -147,6 +148,7 @@ func setupViper(v *viper.Viper) {
 	v.RegisterAlias("api_key", "api-key")
 	v.RegisterAlias("verbose_file", "verbose-file")
 	v.RegisterAlias("verbose_credentials", "verbose-credentials")
+	v.RegisterAlias("track_format", "track-format")
 }
 
 // populateValidArgs dynamically generates the validargs for all of the cobra
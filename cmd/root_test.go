@@ -204,7 +204,7 @@ func TestInitApp(t *testing.T) {
 				`missing ecctl config file, please use the "ecctl init" command to initialize ecctl`,
 				multierror.NewPrefixed(
 					"invalid configuration options specified",
-					errors.New("output must be one either json or text"),
+					errors.New("output must be one of json, ndjson or text"),
 					errors.New("api_key or user and pass must be specified"),
 				),
 			),
@@ -249,6 +249,7 @@ func TestInitApp(t *testing.T) {
 				UserAgent:    strings.Join([]string{"ecctl", versionInfo.Version}, "/"),
 				Timeout:      30 * time.Second,
 				Output:       "text",
+				TrackFormat:  "text",
 				Region:       "ece-region",
 
 				// Verbose settings.
@@ -279,6 +280,7 @@ func TestInitApp(t *testing.T) {
 				UserAgent:    strings.Join([]string{"ecctl", versionInfo.Version}, "/"),
 				Timeout:      30 * time.Second,
 				Output:       "text",
+				TrackFormat:  "text",
 				Region:       "ece-region",
 			},
 		},
@@ -311,6 +313,7 @@ func TestInitApp(t *testing.T) {
 				UserAgent:    strings.Join([]string{"ecctl", versionInfo.Version}, "/"),
 				Timeout:      30 * time.Second,
 				Output:       "text",
+				TrackFormat:  "text",
 				Region:       "ece-region",
 
 				// Verbose settings.
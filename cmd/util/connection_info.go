@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/ecctl/pkg/connectioninfo"
+)
+
+// AddConnectionInfoFlags adds the flags which control the connection info
+// output format to the passed command reference.
+func AddConnectionInfoFlags(cmd *cobra.Command) {
+	cmd.Flags().String("as", connectioninfo.FormatDotenv, fmt.Sprintf(
+		"Connection info output format [%s]", strings.Join(connectioninfo.Formats, "|"),
+	))
+	cmd.Flags().String("name", "", "Optional Kubernetes manifest name, defaults to the sanitized name")
+	cmd.Flags().String("namespace", "", "Optional Kubernetes manifest namespace")
+}
+
+// GetConnectionInfoWriteParams obtains the connection info output settings.
+func GetConnectionInfoWriteParams(cmd *cobra.Command) connectioninfo.WriteParams {
+	format, _ := cmd.Flags().GetString("as")
+	name, _ := cmd.Flags().GetString("name")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	return connectioninfo.WriteParams{Format: format, Name: name, Namespace: namespace}
+}
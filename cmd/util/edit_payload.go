@@ -0,0 +1,254 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdutil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// PayloadFormatJSON opens the payload in the editor as JSON.
+	PayloadFormatJSON = "json"
+	// PayloadFormatYAML opens the payload in the editor as YAML.
+	PayloadFormatYAML = "yaml"
+
+	// PayloadFormatFlagMessage is used as the description of the flag which
+	// sets the format of the payload opened in the editor.
+	PayloadFormatFlagMessage = "Format of the payload opened in the editor [json|yaml]"
+
+	editPayloadHeader = `# Please edit the payload below. Lines beginning with a '#' at the top of
+# the file are ignored, and an empty file aborts the edit.
+`
+)
+
+// ErrEditCancelled is returned by EditPayload when the edited payload is
+// empty.
+var ErrEditCancelled = errors.New("edit cancelled, the payload is empty")
+
+// EditPayloadParams is consumed by EditPayload.
+type EditPayloadParams struct {
+	// Payload is encoded in the specified format and opened in the editor.
+	Payload interface{}
+
+	// Decode decodes and validates the edited payload, which is always
+	// passed as JSON regardless of the format used in the editor. DecodeStrict
+	// can be used to reject any unknown fields.
+	Decode func(data []byte) error
+
+	// Format is the format of the payload opened in the editor, defaults to
+	// PayloadFormatJSON.
+	Format string
+
+	// Output is where the diff of the edited payload is written.
+	Output io.Writer
+}
+
+// Validate ensures the parameters are usable by EditPayload.
+func (params EditPayloadParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid edit payload params")
+	if params.Payload == nil {
+		merr = merr.Append(errors.New("payload cannot be empty"))
+	}
+
+	if params.Decode == nil {
+		merr = merr.Append(errors.New("decode function cannot be nil"))
+	}
+
+	if params.Output == nil {
+		merr = merr.Append(errors.New("output device cannot be nil"))
+	}
+
+	switch params.Format {
+	case "", PayloadFormatJSON, PayloadFormatYAML:
+	default:
+		merr = merr.Append(fmt.Errorf("invalid payload format %q, must be one of [json|yaml]", params.Format))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// EditPayload opens the payload in the user's editor and decodes the edited
+// payload. When the edited payload can't be decoded, the editor is re-opened
+// with the errors as comments at the top of the file, until either the payload
+// is valid or it is saved without any changes. Once the payload is valid, the
+// diff against the original payload is written to the output. It returns false
+// when the payload wasn't modified.
+func EditPayload(params EditPayloadParams) (bool, error) {
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+
+	original, err := encodePayload(params.Payload, params.Format)
+	if err != nil {
+		return false, err
+	}
+
+	var edited = original
+	var header = editPayloadHeader
+	for {
+		b, err := EditContents([]byte(header+edited), "ecctl-edit-*."+payloadExtension(params.Format))
+		if err != nil {
+			return false, err
+		}
+
+		contents := stripHeaderComments(string(b))
+		if strings.TrimSpace(contents) == "" {
+			return false, ErrEditCancelled
+		}
+
+		if contents == original {
+			_, err := fmt.Fprintln(params.Output, "No changes to the payload")
+			return false, err
+		}
+
+		decodeErr := decodePayload([]byte(contents), params.Format, params.Decode)
+		if decodeErr == nil {
+			edited = contents
+			break
+		}
+
+		// Saving an invalid payload without any changes means the user has
+		// given up on fixing it.
+		if header != editPayloadHeader && contents == edited {
+			return false, fmt.Errorf("the edited payload is invalid: %w", decodeErr)
+		}
+
+		edited, header = contents, editPayloadHeader+errorComments(decodeErr)
+	}
+
+	diff, err := UnifiedDiff(original, edited, "current", "edited")
+	if err != nil {
+		return false, err
+	}
+
+	_, err = fmt.Fprint(params.Output, diff)
+	return true, err
+}
+
+// DecodeStrict decodes the JSON data into v, returning an error when the data
+// contains any fields which aren't part of v.
+func DecodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+func payloadExtension(format string) string {
+	if format == PayloadFormatYAML {
+		return "yaml"
+	}
+	return "json"
+}
+
+func encodePayload(payload interface{}, format string) (string, error) {
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	if format != PayloadFormatYAML {
+		return string(b) + "\n", nil
+	}
+
+	// Decoding the JSON into a node keeps the field order of the payload,
+	// resetting the style renders it as block YAML instead of flow.
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return "", err
+	}
+	resetNodeStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		resetNodeStyle(n)
+	}
+}
+
+func decodePayload(data []byte, format string, decode func([]byte) error) error {
+	if format != PayloadFormatYAML {
+		return decode(data)
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return decode(b)
+}
+
+// stripHeaderComments removes the comment lines at the top of the contents.
+// Only the leading lines are removed since YAML payloads can contain strings
+// with lines which begin with a '#'.
+func stripHeaderComments(contents string) string {
+	for contents != "" {
+		line := contents
+		if i := strings.IndexByte(contents, '\n'); i >= 0 {
+			line = contents[:i+1]
+		}
+
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			break
+		}
+		contents = contents[len(line):]
+	}
+	return contents
+}
+
+func errorComments(err error) string {
+	var buf strings.Builder
+	buf.WriteString("#\n# The edited payload is invalid:\n")
+
+	scanner := bufio.NewScanner(strings.NewReader(err.Error()))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			fmt.Fprintf(&buf, "#   %s\n", line)
+		}
+	}
+	buf.WriteString("#\n")
+
+	return buf.String()
+}
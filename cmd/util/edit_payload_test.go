@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditPayload(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+
+	var dir = t.TempDir()
+	var script = filepath.Join(dir, "editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+if grep -q "is invalid" "$1"; then
+  cp "$1" "$(dirname "$0")/reopened"
+  sed -i 's/"invalid"/"new"/' "$1"
+else
+  sed -i 's/"old"/"invalid"/' "$1"
+fi
+`), 0700))
+
+	tests := []struct {
+		name     string
+		editor   string
+		format   string
+		want     *payload
+		wantOut  string
+		wantErr  string
+		reopened string
+	}{
+		{
+			name:   "edits the payload as JSON",
+			editor: `sed -i s/"old"/"new"/`,
+			want:   &payload{Name: "new", Size: 1},
+			wantOut: `--- current
++++ edited
+@@ -1,4 +1,4 @@
+ {
+-  "name": "old",
++  "name": "new",
+   "size": 1
+ }
+`,
+		},
+		{
+			name:   "edits the payload as YAML",
+			editor: "sed -i s/old$/new/",
+			format: PayloadFormatYAML,
+			want:   &payload{Name: "new", Size: 1},
+			wantOut: `--- current
++++ edited
+@@ -1,2 +1,2 @@
+-name: old
++name: new
+ size: 1
+`,
+		},
+		{
+			name:    "returns when there are no changes",
+			editor:  "true",
+			wantOut: "No changes to the payload\n",
+		},
+		{
+			name:    "cancels the edit when the payload is empty",
+			editor:  "sed -i d",
+			wantErr: ErrEditCancelled.Error(),
+		},
+		{
+			name:    "fails when the invalid payload is saved without changes",
+			editor:  `sed -i s/"old"/"invalid"/`,
+			wantErr: `the edited payload is invalid: name cannot be "invalid"`,
+		},
+		{
+			name:   "re-opens the editor with the errors",
+			editor: script,
+			want:   &payload{Name: "new", Size: 1},
+			wantOut: `--- current
++++ edited
+@@ -1,4 +1,4 @@
+ {
+-  "name": "old",
++  "name": "new",
+   "size": 1
+ }
+`,
+			reopened: editPayloadHeader + `#
+# The edited payload is invalid:
+#   name cannot be "invalid"
+#
+{
+  "name": "invalid",
+  "size": 1
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", "")
+			t.Setenv("EDITOR", tt.editor)
+
+			var got *payload
+			var out bytes.Buffer
+			changed, err := EditPayload(EditPayloadParams{
+				Payload: payload{Name: "old", Size: 1},
+				Format:  tt.format,
+				Output:  &out,
+				Decode: func(data []byte) error {
+					var p payload
+					if err := DecodeStrict(data, &p); err != nil {
+						return err
+					}
+					if p.Name == "invalid" {
+						return errors.New(`name cannot be "invalid"`)
+					}
+					got = &p
+					return nil
+				},
+			})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want != nil, changed)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOut, out.String())
+
+			if tt.reopened != "" {
+				b, err := os.ReadFile(filepath.Join(dir, "reopened"))
+				require.NoError(t, err)
+				assert.Equal(t, tt.reopened, string(b))
+			}
+		})
+	}
+}
+
+func TestEditPayloadParamsValidate(t *testing.T) {
+	err := EditPayloadParams{Format: "toml"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid payload format "toml", must be one of [json|yaml]`)
+	assert.Contains(t, err.Error(), "decode function cannot be nil")
+}
+
+func TestDecodeStrict(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	assert.NoError(t, DecodeStrict([]byte(`{"name": "a"}`), &v))
+	assert.EqualError(t, DecodeStrict([]byte(`{"nmae": "a"}`), &v), `json: unknown field "nmae"`)
+}
@@ -113,3 +113,23 @@ func MustUseAFlag(cmd *cobra.Command, first, second string) error {
 	}
 	return nil
 }
+
+// ExactlyOneFlag checks that exactly one of the specified flags is used,
+// returning an error when none or more than one of them have been specified.
+func ExactlyOneFlag(cmd *cobra.Command, names ...string) error {
+	var changed, all = make([]string, 0, len(names)), make([]string, 0, len(names))
+	for _, name := range names {
+		all = append(all, fmt.Sprintf(`"--%s"`, name))
+		if cmd.Flag(name).Changed {
+			changed = append(changed, all[len(all)-1])
+		}
+	}
+
+	switch {
+	case len(changed) == 0:
+		return fmt.Errorf("necessary flags: one of %s should be used", strings.Join(all, ", "))
+	case len(changed) > 1:
+		return fmt.Errorf("conflicting flags: %s should not be used together", strings.Join(changed, ", "))
+	}
+	return nil
+}
@@ -299,3 +299,41 @@ func TestMustUseAFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestExactlyOneFlag(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "something"}
+		cmd.Flags().String("file", "", "file")
+		cmd.Flags().String("query", "", "query")
+		cmd.Flags().Bool("all", false, "all")
+		cmd.ParseFlags(args)
+		return cmd
+	}
+	tests := []struct {
+		name string
+		cmd  *cobra.Command
+		err  error
+	}{
+		{
+			name: "returns an error when no flag is specified",
+			cmd:  newCmd(),
+			err:  errors.New(`necessary flags: one of "--file", "--query", "--all" should be used`),
+		},
+		{
+			name: "returns an error when more than one flag is specified",
+			cmd:  newCmd("--file=query.json", "--all"),
+			err:  errors.New(`conflicting flags: "--file", "--all" should not be used together`),
+		},
+		{
+			name: "returns no error when one flag is specified",
+			cmd:  newCmd("--query={}"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ExactlyOneFlag(tt.cmd, "file", "query", "all"); !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("ExactlyOneFlag() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -48,6 +48,8 @@ type MockCfg struct {
 
 	Force   bool
 	Verbose bool
+
+	SavedSearches map[string]string
 }
 
 func fillDefaults(cfg MockCfg) MockCfg {
@@ -83,6 +85,8 @@ func newConfig(cfg MockCfg) ecctl.Config {
 		APIKey:       defaultAPIKey,
 		Force:        cfg.Force,
 		Verbose:      cfg.Verbose,
+
+		SavedSearches: cfg.SavedSearches,
 	}
 }
 
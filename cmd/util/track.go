@@ -24,6 +24,7 @@ import (
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
 
+	"github.com/elastic/ecctl/pkg/ecctl"
 	"github.com/elastic/ecctl/pkg/formatter"
 )
 
@@ -56,7 +57,8 @@ func (params TrackParams) Validate() error {
 }
 
 // Track will either print and track the parameter specified Response.
-// If the formatter is not specified an error will be returned.
+// If the formatter is not specified an error will be returned. When the
+// format is ndjson, the plan change is tracked with TrackEvents.
 func Track(params TrackParams) error {
 	if err := params.Validate(); err != nil {
 		return err
@@ -75,5 +77,9 @@ func Track(params TrackParams) error {
 		return nil
 	}
 
+	if params.Format == ecctl.NDJSONOutput {
+		return TrackEvents(params.TrackChangeParams)
+	}
+
 	return planutil.TrackChange(params.TrackChangeParams)
 }
@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdutil
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+)
+
+const (
+	// TrackEventStep is the type of the events emitted on each plan step
+	// transition of a deployment resource.
+	TrackEventStep = "step"
+	// TrackEventResult is the type of the event emitted once the plan
+	// change of a deployment has finished.
+	TrackEventResult = "result"
+)
+
+// Statuses of the tracking events.
+const (
+	trackEventRunning   = "running"
+	trackEventError     = "error"
+	trackEventSucceeded = "succeeded"
+	trackEventFailed    = "failed"
+)
+
+// TrackEvent is a machine-readable plan tracking event.
+type TrackEvent struct {
+	Type         string `json:"type"`
+	DeploymentID string `json:"deployment_id"`
+	Kind         string `json:"kind,omitempty"`
+	RefID        string `json:"ref_id,omitempty"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	StepID       string `json:"step_id,omitempty"`
+	Status       string `json:"status"`
+
+	// Timestamp is the time at which the event was observed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// StartedAt is the time at which the plan tracking started, only set in
+	// the result events.
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Duration is the plan duration reported by the API for step events, and
+	// the tracking duration for result events.
+	Duration string `json:"duration,omitempty"`
+
+	Error          string               `json:"error,omitempty"`
+	FailureDetails *plan.FailureDetails `json:"failure_details,omitempty"`
+}
+
+// TrackEventWriter writes a JSON TrackEvent per line on each plan step
+// transition of the tracked resources. It's safe to use it concurrently to
+// track multiple deployments.
+type TrackEventWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	last    map[string]TrackEvent
+	now     func() time.Time
+}
+
+// NewTrackEventWriter returns a TrackEventWriter which writes to w.
+func NewTrackEventWriter(w io.Writer) *TrackEventWriter {
+	return &TrackEventWriter{
+		encoder: json.NewEncoder(w),
+		last:    make(map[string]TrackEvent),
+		now:     time.Now,
+	}
+}
+
+// Now returns the current time as seen by the writer.
+func (w *TrackEventWriter) Now() time.Time { return w.now() }
+
+// Step writes a step event when the response's resource has transitioned to
+// a different step or status than the last one written.
+func (w *TrackEventWriter) Step(res plan.TrackResponse) {
+	var event = TrackEvent{
+		Type:           TrackEventStep,
+		DeploymentID:   res.DeploymentID,
+		Kind:           res.Kind,
+		RefID:          res.RefID,
+		ResourceID:     res.ID,
+		StepID:         res.Step,
+		Status:         trackEventRunning,
+		Duration:       time.Duration(res.Duration).String(),
+		FailureDetails: res.FailureDetails,
+	}
+
+	var failed = res.Err != nil && res.Err != plan.ErrPlanFinished
+	if failed {
+		event.Status = trackEventError
+		event.Error = FlattenTrackError(res.Err)
+	}
+	if res.Finished {
+		event.Status = trackEventSucceeded
+		if failed {
+			event.Status = trackEventFailed
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var key = res.DeploymentID + "/" + res.ID
+	if last, ok := w.last[key]; ok && last.StepID == event.StepID &&
+		last.Status == event.Status && last.Error == event.Error {
+		return
+	}
+	w.last[key] = event
+
+	event.Timestamp = w.now()
+	_ = w.encoder.Encode(event)
+}
+
+// Result writes the result event of the deployment's plan change, which
+// started at the specified time and finished with err.
+func (w *TrackEventWriter) Result(deploymentID string, started time.Time, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var now = w.now()
+	var event = TrackEvent{
+		Type:         TrackEventResult,
+		DeploymentID: deploymentID,
+		Status:       trackEventSucceeded,
+		Timestamp:    now,
+		StartedAt:    &started,
+		Duration:     now.Sub(started).String(),
+	}
+	if err != nil {
+		event.Status = trackEventFailed
+		event.Error = FlattenTrackError(err)
+	}
+
+	_ = w.encoder.Encode(event)
+}
+
+// TrackEvents tracks the plan change, writing a TrackEvent per line on each
+// plan step transition to the Writer, followed by a result event once the
+// plan change has finished. The returned error is the one which made the plan
+// fail, if any.
+func TrackEvents(params planutil.TrackChangeParams) error {
+	if params.Writer == nil {
+		return multierror.NewPrefixed("plan track change",
+			errors.New("writer needs to be specified when format is not empty"),
+		)
+	}
+
+	var events = NewTrackEventWriter(params.Writer)
+	var started = events.Now()
+	channel, err := plan.TrackChange(params.TrackChangeParams)
+	if err != nil {
+		return multierror.NewPrefixed("plan track change", err)
+	}
+
+	var deploymentID = params.DeploymentID
+	err = plan.StreamFunc(channel, func(res plan.TrackResponse) {
+		deploymentID = res.DeploymentID
+		events.Step(res)
+	})
+	events.Result(deploymentID, started, err)
+
+	return err
+}
+
+// FlattenTrackError joins multi-line errors so they fit in a single line.
+func FlattenTrackError(err error) string {
+	return strings.Join(strings.Fields(err.Error()), " ")
+}
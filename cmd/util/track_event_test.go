@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackEventWriter(t *testing.T) {
+	const id = "cbb4bc6c09684c86aa5de54c05ea1d38"
+	var started = time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+	var now = started
+
+	var buf bytes.Buffer
+	events := NewTrackEventWriter(&buf)
+	events.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	var res = plan.TrackResponse{
+		ID: "cde7b6b605424a54ce9d56316eab13a1", Kind: "elasticsearch", RefID: "main-elasticsearch",
+		DeploymentID: id, Step: "step-1", Duration: strfmt.Duration(time.Second),
+	}
+	events.Step(res)
+	// The same step isn't written twice.
+	events.Step(res)
+
+	res.Step, res.Duration = "step-2", strfmt.Duration(2*time.Second)
+	events.Step(res)
+
+	res.Step, res.Finished = "plan-completed", true
+	res.Err = errors.New("horrible failure")
+	res.FailureDetails = &plan.FailureDetails{FailureType: "InfrastructureFailure:TimeoutExceeded"}
+	events.Step(res)
+
+	events.Result(id, started, multierror.NewPrefixed("found deployment plan errors", res))
+
+	assert.Equal(t, `{"type":"step","deployment_id":"cbb4bc6c09684c86aa5de54c05ea1d38","kind":"elasticsearch","ref_id":"main-elasticsearch","resource_id":"cde7b6b605424a54ce9d56316eab13a1","step_id":"step-1","status":"running","timestamp":"2024-10-01T10:00:01Z","duration":"1s"}
+{"type":"step","deployment_id":"cbb4bc6c09684c86aa5de54c05ea1d38","kind":"elasticsearch","ref_id":"main-elasticsearch","resource_id":"cde7b6b605424a54ce9d56316eab13a1","step_id":"step-2","status":"running","timestamp":"2024-10-01T10:00:02Z","duration":"2s"}
+{"type":"step","deployment_id":"cbb4bc6c09684c86aa5de54c05ea1d38","kind":"elasticsearch","ref_id":"main-elasticsearch","resource_id":"cde7b6b605424a54ce9d56316eab13a1","step_id":"plan-completed","status":"failed","timestamp":"2024-10-01T10:00:03Z","duration":"2s","error":"horrible failure","failure_details":{"details":null,"failure_type":"InfrastructureFailure:TimeoutExceeded"}}
+{"type":"result","deployment_id":"cbb4bc6c09684c86aa5de54c05ea1d38","status":"failed","timestamp":"2024-10-01T10:00:04Z","started_at":"2024-10-01T10:00:00Z","duration":"4s","error":"found deployment plan errors: 1 error occurred: * deployment [cbb4bc6c09684c86aa5de54c05ea1d38] - [elasticsearch][cde7b6b605424a54ce9d56316eab13a1]: caught error: \"horrible failure\""}
+`, buf.String())
+}
+
+func TestTrackEvents(t *testing.T) {
+	err := TrackEvents(planutil.TrackChangeParams{})
+	assert.EqualError(t, err, multierror.NewPrefixed("plan track change",
+		errors.New("writer needs to be specified when format is not empty"),
+	).Error())
+}
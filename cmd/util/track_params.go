@@ -52,6 +52,18 @@ type TrackParamsConfig struct {
 	FrequencyConfig *plan.TrackFrequencyConfig
 }
 
+// TrackFormat returns the format used to track plan changes, which is ndjson
+// when either the output or the track format are set to it, or the output is
+// json. Otherwise, the output format is used.
+func TrackFormat(c ecctl.Config) string {
+	switch {
+	case c.Output == ecctl.JSONOutput, c.Output == ecctl.NDJSONOutput, c.TrackFormat == ecctl.NDJSONOutput:
+		return ecctl.NDJSONOutput
+	default:
+		return c.Output
+	}
+}
+
 // NewTrackParams creates a TrackParams structure from the config.
 func NewTrackParams(params TrackParamsConfig) TrackParams {
 	if params.FrequencyConfig == nil {
@@ -69,7 +81,7 @@ func NewTrackParams(params TrackParamsConfig) TrackParams {
 	return TrackParams{
 		TrackChangeParams: planutil.TrackChangeParams{
 			Writer: params.App.Config.OutputDevice,
-			Format: params.App.Config.Output,
+			Format: TrackFormat(params.App.Config),
 			TrackChangeParams: plan.TrackChangeParams{
 				API:          params.App.API,
 				DeploymentID: params.DeploymentID,
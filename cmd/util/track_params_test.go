@@ -123,3 +123,24 @@ func TestNewTrackParams(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ecctl.Config
+		want   string
+	}{
+		{name: "text output", config: ecctl.Config{Output: "text"}, want: "text"},
+		{name: "text output and track format", config: ecctl.Config{Output: "text", TrackFormat: "text"}, want: "text"},
+		{name: "ndjson track format", config: ecctl.Config{Output: "text", TrackFormat: "ndjson"}, want: "ndjson"},
+		{name: "json output", config: ecctl.Config{Output: "json", TrackFormat: "text"}, want: "ndjson"},
+		{name: "ndjson output", config: ecctl.Config{Output: "ndjson"}, want: "ndjson"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrackFormat(tt.config); got != tt.want {
+				t.Errorf("TrackFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package connectioninfo builds the connection information of deployments and
+// serverless projects, and writes it in formats which applications can consume.
+package connectioninfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/ecctl/pkg/project"
+)
+
+// Supported output formats.
+const (
+	// FormatEnv writes shell export statements.
+	FormatEnv = "env"
+	// FormatDotenv writes KEY=value lines, as used by .env files.
+	FormatDotenv = "dotenv"
+	// FormatJSON writes the connection information as JSON.
+	FormatJSON = "json"
+	// FormatSecret writes a Kubernetes Secret manifest.
+	FormatSecret = "secret"
+	// FormatConfigMap writes a Kubernetes ConfigMap manifest.
+	FormatConfigMap = "configmap"
+)
+
+// Formats contains all the supported output formats.
+var Formats = []string{FormatEnv, FormatDotenv, FormatJSON, FormatSecret, FormatConfigMap}
+
+const (
+	cloudIDKey  = "ELASTIC_CLOUD_ID"
+	defaultPort = 443
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Info is the connection information of a deployment or project.
+type Info struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	CloudID   string     `json:"cloud_id,omitempty"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is the endpoint of a single service.
+type Endpoint struct {
+	Service string `json:"service"`
+	URL     string `json:"url"`
+	Host    string `json:"host"`
+	Port    int    `json:"port"`
+}
+
+// NewFromDeployment returns the connection information of the deployment
+// resources, in the Elasticsearch, Kibana, APM, Integrations Server,
+// Enterprise Search and App Search order.
+func NewFromDeployment(res *models.DeploymentGetResponse) (*Info, error) {
+	var info Info
+	if res.ID != nil {
+		info.ID = *res.ID
+	}
+	if res.Name != nil {
+		info.Name = *res.Name
+	}
+	if res.Resources == nil {
+		return &info, nil
+	}
+
+	var metadata []kindMetadata
+	for _, r := range res.Resources.Elasticsearch {
+		if r.Info != nil {
+			metadata = append(metadata, kindMetadata{util.Elasticsearch, r.Info.Metadata})
+		}
+	}
+	for _, r := range res.Resources.Kibana {
+		if r.Info != nil {
+			metadata = append(metadata, kindMetadata{util.Kibana, r.Info.Metadata})
+		}
+	}
+	for _, r := range res.Resources.Apm {
+		if r.Info != nil {
+			metadata = append(metadata, kindMetadata{util.Apm, r.Info.Metadata})
+		}
+	}
+	for _, r := range res.Resources.IntegrationsServer {
+		if r.Info != nil {
+			metadata = append(metadata, kindMetadata{util.IntegrationsServer, r.Info.Metadata})
+		}
+	}
+	for _, r := range res.Resources.EnterpriseSearch {
+		if r.Info != nil {
+			metadata = append(metadata, kindMetadata{util.EnterpriseSearch, r.Info.Metadata})
+		}
+	}
+	for _, r := range res.Resources.Appsearch {
+		if r.Info != nil {
+			metadata = append(metadata, kindMetadata{util.Appsearch, r.Info.Metadata})
+		}
+	}
+
+	for _, m := range metadata {
+		if m.metadata == nil {
+			continue
+		}
+
+		if info.CloudID == "" {
+			info.CloudID = m.metadata.CloudID
+		}
+
+		if err := info.addMetadataEndpoints(m.kind, m.metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return &info, nil
+}
+
+// NewFromProject returns the connection information of the serverless project
+// endpoints, sorted by service.
+func NewFromProject(p *project.Project) (*Info, error) {
+	var info = Info{ID: p.ID, Name: p.Name, CloudID: p.CloudID}
+
+	services := make([]string, 0, len(p.Endpoints))
+	for service := range p.Endpoints {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		if err := info.addEndpoint(service, p.Endpoints[service], 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return &info, nil
+}
+
+type kindMetadata struct {
+	kind     string
+	metadata *models.ClusterMetadataInfo
+}
+
+// addMetadataEndpoints adds the resource endpoint, preferring the aliased URL,
+// and the endpoints of any additional services which the resource exposes.
+func (info *Info) addMetadataEndpoints(kind string, m *models.ClusterMetadataInfo) error {
+	var port int
+	if m.Ports != nil && m.Ports.HTTPS != nil {
+		port = int(*m.Ports.HTTPS)
+	}
+
+	var rawURL = m.AliasedURL
+	if rawURL == "" {
+		rawURL = m.ServiceURL
+	}
+	if rawURL == "" && m.Endpoint != "" {
+		rawURL = "https://" + m.Endpoint
+	}
+
+	if err := info.addEndpoint(kind, rawURL, port); err != nil {
+		return err
+	}
+
+	for _, s := range m.ServicesUrls {
+		if s == nil || s.Service == nil || s.URL == nil {
+			continue
+		}
+		if err := info.addEndpoint(*s.Service, *s.URL, port); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addEndpoint adds the endpoint of the service, unless the URL is empty or
+// the service already has an endpoint. When the URL doesn't specify a port,
+// the fallback port is used, or 443 when it's not set.
+func (info *Info) addEndpoint(service, rawURL string, fallbackPort int) error {
+	if rawURL == "" {
+		return nil
+	}
+	for _, e := range info.Endpoints {
+		if e.Service == service {
+			return nil
+		}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid %s endpoint: %w", service, err)
+	}
+
+	var port = fallbackPort
+	if p := u.Port(); p != "" {
+		if port, err = strconv.Atoi(p); err != nil {
+			return fmt.Errorf("invalid %s endpoint port: %w", service, err)
+		}
+	}
+	if port == 0 {
+		port = defaultPort
+	}
+
+	info.Endpoints = append(info.Endpoints, Endpoint{
+		Service: service,
+		URL:     rawURL,
+		Host:    u.Hostname(),
+		Port:    port,
+	})
+	return nil
+}
+
+// Variable is a single connection information variable.
+type Variable struct {
+	Key   string
+	Value string
+}
+
+// Variables returns the connection information as environment variables, the
+// cloud ID followed by the URL, host and port of each endpoint.
+func (info Info) Variables() []Variable {
+	var vars []Variable
+	if info.CloudID != "" {
+		vars = append(vars, Variable{Key: cloudIDKey, Value: info.CloudID})
+	}
+
+	for _, e := range info.Endpoints {
+		prefix := strings.ToUpper(e.Service)
+		vars = append(vars,
+			Variable{Key: prefix + "_URL", Value: e.URL},
+			Variable{Key: prefix + "_HOST", Value: e.Host},
+			Variable{Key: prefix + "_PORT", Value: strconv.Itoa(e.Port)},
+		)
+	}
+
+	return vars
+}
+
+// WriteParams is consumed by Write.
+type WriteParams struct {
+	// Format is one of the supported formats, defaults to FormatDotenv.
+	Format string
+
+	// Name of the Kubernetes manifest, defaults to the sanitized name of the
+	// deployment or project, or its ID when the name is empty.
+	Name string
+
+	// Namespace of the Kubernetes manifest, omitted when empty.
+	Namespace string
+}
+
+// Write writes the connection information in the specified format.
+func (info Info) Write(w io.Writer, params WriteParams) error {
+	switch params.Format {
+	case "", FormatDotenv:
+		return writeVariables(w, "", info.Variables())
+	case FormatEnv:
+		return writeVariables(w, "export ", info.Variables())
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	case FormatSecret, FormatConfigMap:
+		return info.writeManifest(w, params)
+	default:
+		return fmt.Errorf(`invalid connection info format "%s", must be one of [%s]`,
+			params.Format, strings.Join(Formats, "|"),
+		)
+	}
+}
+
+// writeVariables writes the variables as KEY='value' lines. The values are
+// single-quoted since they can contain the deployment name, which may include
+// spaces or shell metacharacters.
+func writeVariables(w io.Writer, prefix string, vars []Variable) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(w, "%s%s=%s\n", prefix, v.Key, shellQuote(v.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shellQuote wraps the value in single quotes, closing and reopening the quoted
+// string around an escaped single quote for each one the value contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+type manifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   manifestMetadata  `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+	Data       map[string]string `yaml:"data,omitempty"`
+}
+
+type manifestMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+func (info Info) writeManifest(w io.Writer, params WriteParams) error {
+	var name = params.Name
+	if name == "" {
+		name = manifestName(info.Name)
+	}
+	if name == "" {
+		name = manifestName(info.ID)
+	}
+
+	var data = make(map[string]string)
+	for _, v := range info.Variables() {
+		data[v.Key] = v.Value
+	}
+
+	var m = manifest{
+		APIVersion: "v1",
+		Metadata:   manifestMetadata{Name: name, Namespace: params.Namespace},
+	}
+	if params.Format == FormatSecret {
+		m.Kind, m.Type, m.StringData = "Secret", "Opaque", data
+	} else {
+		m.Kind, m.Data = "ConfigMap", data
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// manifestName converts the name into a valid Kubernetes resource name.
+func manifestName(name string) string {
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.Trim(name, "-")
+}
@@ -19,6 +19,7 @@ package ecctl
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
@@ -32,6 +33,10 @@ type App struct {
 	API       *api.API
 	Formatter formatter.Formatter
 	Config    Config
+
+	// transport is the config's client transport before it's wrapped by the
+	// API with the verbose and retry settings.
+	transport http.RoundTripper
 }
 
 // NewApplication returns a fully initialized App, which will be called from the presentation layer (cmd)
@@ -41,6 +46,11 @@ func NewApplication(c Config) (*App, error) {
 		return nil, err
 	}
 
+	var transport http.RoundTripper
+	if c.Client != nil {
+		transport = c.Client.Transport
+	}
+
 	apiInstance, err := api.NewAPI(cfg)
 	if err != nil {
 		return nil, err
@@ -61,9 +71,40 @@ func NewApplication(c Config) (*App, error) {
 		API:       apiInstance,
 		Formatter: fmter,
 		Config:    c,
+		transport: transport,
 	}, nil
 }
 
+// NewNonVerboseAPI returns a new API instance created from the application's
+// config with the verbose output disabled. It's meant to be used for requests
+// which return credentials, which must never be written to the verbose output.
+func (a *App) NewNonVerboseAPI() (*api.API, error) {
+	var c = a.Config
+	c.Verbose, c.VerboseFile = false, ""
+
+	// The client's transport is wrapped by the API, so a new client which
+	// uses the original transport is needed for the verbose settings to apply.
+	c.Client = a.NewNonVerboseClient()
+
+	cfg, err := newAPIConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewAPI(cfg)
+}
+
+// NewNonVerboseClient returns a new HTTP client which uses the config client's
+// transport before it's wrapped with the verbose settings. It's meant to be
+// used for requests which are sent directly and contain credentials.
+func (a *App) NewNonVerboseClient() *http.Client {
+	var client = &http.Client{Transport: a.transport}
+	if a.Config.Client != nil {
+		client.Timeout = a.Config.Client.Timeout
+	}
+	return client
+}
+
 func newAPIConfig(cfg Config) (api.Config, error) {
 	var empty api.Config
 	if err := cfg.Validate(); err != nil {
@@ -33,11 +33,15 @@ const (
 	JSONOutput = "json"
 	// TextOutput is the text (templated) output format
 	TextOutput = "text"
+	// NDJSONOutput is the newline delimited json output format, which allows
+	// the commands that support it to stream their results.
+	NDJSONOutput = "ndjson"
 )
 
 var (
 	errCannotSpecifyJSONOutputAndCustomFormat = errors.New("cannot specify json output with format flag")
-	errInvalidOutputFormat                    = errors.New("output must be one either json or text")
+	errInvalidOutputFormat                    = errors.New("output must be one of json, ndjson or text")
+	errInvalidTrackFormat                     = errors.New("track format must be one of ndjson or text")
 	errInvalidOutputDevice                    = errors.New("output device must not be nil")
 	errInvalidErrorDevice                     = errors.New("error device must not be nil")
 	errInvalidEmptyAuthenticaitonSettings     = errors.New("api_key or user and pass must be specified")
@@ -56,6 +60,11 @@ type Config struct {
 	Format      string `json:"format,omitempty"`
 	VerboseFile string `json:"verbose_file,omitempty" mapstructure:"verbose_file"`
 
+	// TrackFormat is the format used to track plan changes, ndjson emits a
+	// JSON event on each plan step transition. Tracking always uses ndjson
+	// when the Output is json or ndjson.
+	TrackFormat string `json:"track_format,omitempty" mapstructure:"track_format"`
+
 	OutputDevice *output.Device `json:"-"`
 	ErrorDevice  io.Writer      `json:"-"`
 	Client       *http.Client   `json:"-"`
@@ -67,6 +76,10 @@ type Config struct {
 	Force              bool `json:"force,omitempty"`
 	Insecure           bool `json:"insecure,omitempty"`
 
+	// SavedSearches contains named deployment searches, either a Query DSL
+	// JSON search request or a query string, used by --saved.
+	SavedSearches map[string]string `json:"saved_searches,omitempty" mapstructure:"saved_searches"`
+
 	// SkipLogin skips loging in when user and pass are set.
 	SkipLogin bool `json:"-"`
 
@@ -77,10 +90,14 @@ type Config struct {
 // Validate checks that the application config is a valid one
 func (c *Config) Validate() error {
 	var err = multierror.NewPrefixed("invalid configuration options specified")
-	if !slice.HasString([]string{JSONOutput, TextOutput}, c.Output) {
+	if !slice.HasString([]string{JSONOutput, NDJSONOutput, TextOutput}, c.Output) {
 		err = err.Append(errInvalidOutputFormat)
 	}
 
+	if c.TrackFormat != "" && !slice.HasString([]string{NDJSONOutput, TextOutput}, c.TrackFormat) {
+		err = err.Append(errInvalidTrackFormat)
+	}
+
 	var allCreds = c.APIKey != "" && (c.User != "" || c.Pass != "")
 	if allCreds {
 		err = err.Append(errInvalidBothAuthenticaitonSettings)
@@ -91,7 +108,7 @@ func (c *Config) Validate() error {
 		err = err.Append(errInvalidEmptyAuthenticaitonSettings)
 	}
 
-	if c.Output == JSONOutput && c.Format != "" {
+	if (c.Output == JSONOutput || c.Output == NDJSONOutput) && c.Format != "" {
 		err = err.Append(errCannotSpecifyJSONOutputAndCustomFormat)
 	}
 
@@ -39,6 +39,7 @@ func TestConfigValidate(t *testing.T) {
 		Verbose      bool
 		Message      string
 		Format       string
+		TrackFormat  string
 		OutputDevice *output.Device
 		ErrorDevice  io.Writer
 		Client       *http.Client
@@ -73,6 +74,19 @@ func TestConfigValidate(t *testing.T) {
 				errInvalidErrorDevice,
 			),
 		},
+		{
+			name: "Validate fails when the track format is invalid",
+			fields: fields{
+				Output:      TextOutput,
+				TrackFormat: JSONOutput,
+				APIKey:      "dummy",
+			},
+			err: multierror.NewPrefixed("invalid configuration options specified",
+				errInvalidTrackFormat,
+				errInvalidOutputDevice,
+				errInvalidErrorDevice,
+			),
+		},
 		{
 			name: "Validate fails due to specifying both user / pass and APIKey",
 			fields: fields{
@@ -134,6 +148,7 @@ func TestConfigValidate(t *testing.T) {
 				Verbose:      tt.fields.Verbose,
 				Message:      tt.fields.Message,
 				Format:       tt.fields.Format,
+				TrackFormat:  tt.fields.TrackFormat,
 				OutputDevice: tt.fields.OutputDevice,
 				ErrorDevice:  tt.fields.ErrorDevice,
 				Client:       tt.fields.Client,
@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package esproxy sends requests to the Elasticsearch resources of a
+// deployment through the deployment's Elasticsearch proxy API.
+package esproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+)
+
+// Params are the parameters for sending a request to the Elasticsearch proxy.
+type Params struct {
+	API    *api.API
+	Host   string
+	Client *http.Client
+
+	DeploymentID string
+	// RefID of the Elasticsearch resource, auto-discovered when empty.
+	RefID string
+
+	Method string
+	// Path of the Elasticsearch API, which may contain a query string.
+	Path string
+	Body []byte
+}
+
+// Validate ensures the parameters are usable.
+func (p Params) Validate() error {
+	var merr = multierror.NewPrefixed("invalid elasticsearch proxy params")
+	if p.API == nil {
+		merr = merr.Append(errors.New("api reference is required"))
+	}
+	if p.Host == "" {
+		merr = merr.Append(errors.New("host is required"))
+	}
+	if p.DeploymentID == "" {
+		merr = merr.Append(errors.New("deployment id is required"))
+	}
+	if p.Method == "" {
+		merr = merr.Append(errors.New("method is required"))
+	}
+	return merr.ErrorOrNil()
+}
+
+func (p Params) httpClient() *http.Client {
+	if p.Client != nil {
+		return p.Client
+	}
+	return &http.Client{}
+}
+
+// Response is the raw response returned by Elasticsearch.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Do sends the request to the Elasticsearch proxy and returns the response,
+// regardless of its status code.
+func Do(params Params) (*Response, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.RefID == "" {
+		if err := deploymentapi.PopulateRefID(deploymentapi.PopulateRefIDParams{
+			API:          params.API,
+			DeploymentID: params.DeploymentID,
+			Kind:         util.Elasticsearch,
+			RefID:        &params.RefID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	host := strings.TrimRight(params.Host, "/")
+	endpoint := fmt.Sprintf("%s/api/v1/deployments/%s/elasticsearch/%s/proxy/%s",
+		host, params.DeploymentID, params.RefID, strings.TrimLeft(params.Path, "/"),
+	)
+
+	reqURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint URL: %w", err)
+	}
+
+	var body io.Reader
+	if params.Body != nil {
+		body = bytes.NewReader(params.Body)
+	}
+
+	req, err := http.NewRequest(params.Method, reqURL.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-Management-Request", "true")
+	if params.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req = params.API.AuthWriter.AuthRequest(req)
+
+	resp, err := params.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: b}, nil
+}
+
+// DoJSON sends the request to the Elasticsearch proxy and decodes the JSON
+// response into v, which can be nil to discard it. An error is returned when
+// the response status code isn't 2xx.
+func DoJSON(params Params, v interface{}) error {
+	res, err := Do(params)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("elasticsearch returned status %d: %s", res.StatusCode, strings.TrimSpace(string(res.Body)))
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(res.Body, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -35,7 +35,13 @@ type Formatter interface {
 // JSON Formatter if no known formatter is passed, else
 // It will use the mentioned formatter and use the JSON
 // Formatter to fallback when parsing the output fails.
+// The NDJSON Formatter is used on its own, since falling
+// back to indented JSON would break the line delimiting.
 func New(o io.Writer, name string) *Chain {
+	if name == "ndjson" {
+		return NewChain(NewNDJSON(o))
+	}
+
 	fallbackFormatter := NewChain(NewJSON(o))
 	if name == "text" {
 		fallbackFormatter = NewChain(
@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// NewNDJSON acts as the factory for formatter.NDJSON
+func NewNDJSON(output io.Writer) *NDJSON {
+	return &NDJSON{output}
+}
+
+// NDJSON formats each value as a single line of JSON, so that a
+// command can stream its results by formatting them one by one.
+type NDJSON struct {
+	// Where formatter.NDJSON will output the result
+	o io.Writer
+}
+
+// Name obtains the name of the formatter
+func (f *NDJSON) Name() string { return "ndjson" }
+
+// Format is used from the cmd for conveniency
+// it receives a path and the data to be formatted.
+//
+// The path doesn't have any effect on the NDJSON
+// formatting.
+func (f *NDJSON) Format(path string, data interface{}) error {
+	return json.NewEncoder(f.o).Encode(data)
+}
@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package formatter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNDJSON_Format(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	f := New(buf, "ndjson")
+	for _, data := range []interface{}{
+		&testMarshal{"DataA", "DataB", "DataC"},
+		&testMarshal{A: "DataD"},
+	} {
+		if err := f.Format("deployment/list", data); err != nil {
+			t.Fatalf("NDJSON.Format() error = %v", err)
+		}
+	}
+
+	want := `{"a":"DataA","b":"DataB","c":"DataC"}` + "\n" + `{"a":"DataD","b":"","c":""}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("NDJSON.Format() = %v, want %v", got, want)
+	}
+}
@@ -25,10 +25,21 @@
 // text/comment/create.gotmpl
 // text/comment/list.gotmpl
 // text/comment/show.gotmpl
+// text/deployment/autoscaling.gotmpl
+// text/deployment/bulk.gotmpl
 // text/deployment/eskeystore_show.gotmpl
+// text/deployment/esresetpassword.gotmpl
+// text/deployment/health.gotmpl
 // text/deployment/list.gotmpl
 // text/deployment/notelist.gotmpl
+// text/deployment/planhistory.gotmpl
 // text/deployment/search.gotmpl
+// text/deployment/snapshotlist.gotmpl
+// text/deployment/snapshotsettings.gotmpl
+// text/deployment/snapshotshow.gotmpl
+// text/deployment/taglist.gotmpl
+// text/deployment/track.gotmpl
+// text/deployment/usersettings.gotmpl
 // text/deployment-template/list.gotmpl
 // text/filtered-group/list.gotmpl
 // text/id.gotmpl
@@ -286,6 +297,46 @@ func textCommentShowGotmpl() (*asset, error) {
 	return a, nil
 }
 
+var _textDeploymentAutoscalingGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x8f\x51\x4b\xc3\x30\x14\x85\xdf\xfb\x2b\x2e\x79\x5f\xfe\x43\xb1\x41\x02\x6b\x2b\x36\x82\xf6\x2d\x33\xa7\x12\xa8\xa9\xa4\x9d\x0c\x43\xfe\xbb\x24\x9b\xc3\xd5\xfa\x94\xf0\xdd\x73\xcf\x39\x37\x84\x1d\x19\x0c\xd6\x81\xd8\xf4\x09\xef\xad\x01\xa3\x18\x43\x20\x9c\xf0\x7a\x5c\xa0\xf0\xfe\x31\xea\x05\xc4\x29\xc6\x22\x71\x67\xce\x82\x9f\x3d\x83\x41\x1f\xc7\x25\xad\x15\xc9\x8f\x55\xe2\x61\xdf\xbe\xd4\xa2\x51\x24\xab\x84\x43\x58\xf4\x21\x3d\xc4\xca\x27\xd5\x76\x77\xe5\x5e\x36\xf7\x97\x05\xe2\xb2\xba\xd1\xd8\x81\xb8\x70\xfa\x30\x22\x05\xe1\xfc\x4b\xc1\xe3\x0c\x8a\xd1\xd8\xf9\x4a\x72\x95\x22\x99\x30\x25\xc5\xe3\x2a\xab\x93\xbd\x58\xa1\xbe\x6d\x44\xb7\x62\xb5\x6c\x68\x43\x5a\x97\xcf\x57\x9c\xef\xf2\xda\xbd\x81\xb8\xb2\xf0\xf3\x3f\xd5\x27\x4f\xbc\xb3\x5f\x20\xb6\xbb\x75\xe3\xfd\xe4\x30\xff\xd1\xd6\xd6\x6d\xca\xf3\x4c\x9f\x7e\xcd\x72\x83\xcb\xbd\x21\x10\x9c\xa1\x18\x8b\xef\x01\x00\xa9\xff\x0a\x6d\xbf\x01\x00\x00")
+
+func textDeploymentAutoscalingGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentAutoscalingGotmpl,
+		"text/deployment/autoscaling.gotmpl",
+	)
+}
+
+func textDeploymentAutoscalingGotmpl() (*asset, error) {
+	bytes, err := textDeploymentAutoscalingGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/autoscaling.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentBulkGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8f\xc1\x6a\x83\x40\x10\x86\xef\x3e\xc5\xe0\xb9\xf1\x1d\x02\x6e\x21\xd0\x98\xa2\xf6\xd0\xe3\xd6\xf9\x2d\xc2\x66\x53\xd6\xb5\x14\x86\x79\xf7\xb2\x26\xa6\x26\x39\xf4\xe4\xf0\x8d\xf3\xff\xdf\x8a\x6c\x88\xd1\x0f\x1e\x94\x9f\xbe\x11\xc2\xc0\xc8\x49\x55\x84\x82\xf5\x9f\xa0\xa2\xc6\x38\xb9\x38\x9e\x19\x7e\xd0\x4d\x11\x2d\x8e\x5f\xce\x46\x50\xa1\x9a\x89\x10\x3c\x5f\xf6\xcb\xb0\x64\x32\x7a\x3b\xb9\x98\x22\xb3\xd4\x95\x97\xe6\xf5\xe5\xf0\xbe\x37\x55\x4b\xbb\x32\x61\x91\x68\x3f\xd2\x87\xf2\x6a\xbb\x37\x77\xa8\x69\xb7\xed\x5b\x73\x07\x4d\x5d\x1f\xea\x6b\xe4\x83\x67\x32\x2a\x76\xe5\xcd\xcd\xd0\x53\x51\xd9\x23\x66\xb8\x1e\xe1\xc6\x04\x37\x2b\xf9\xf5\x8d\x09\xe1\x14\x48\xb5\xb7\x83\x03\xff\xfd\x3f\x4e\x5d\x07\x30\xf8\x9f\x3b\x91\x9b\xf9\xa1\x6d\x7e\x01\x3c\x5f\xac\x9b\x25\x96\x54\xe9\xda\xf1\x44\x29\xe6\x79\x56\x48\x8b\xb3\x4c\x26\x02\xcf\xaa\xd9\xef\x00\x1d\x34\x0b\x58\xc4\x01\x00\x00")
+
+func textDeploymentBulkGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentBulkGotmpl,
+		"text/deployment/bulk.gotmpl",
+	)
+}
+
+func textDeploymentBulkGotmpl() (*asset, error) {
+	bytes, err := textDeploymentBulkGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/bulk.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _textDeploymentEskeystore_showGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8e\xc1\x8a\x83\x30\x18\x84\xef\x3e\xc5\x4f\xf0\xb8\xeb\x03\x2c\xec\x41\x4a\x84\x82\xa7\x6a\xbd\xa7\x66\x2c\x81\x98\x96\x18\x8b\x10\xf2\xee\x25\xb1\x52\x84\x42\x4f\x19\x66\x26\xff\x37\xde\xff\x92\xc4\xa0\x0c\x88\xdd\x1e\xb0\x56\x49\x30\x0a\x21\xfa\x56\x98\x2b\x28\x57\x46\x62\xf9\xa1\x1c\x1a\x23\xfd\xfd\x53\xd1\xa0\xb7\x70\x53\x6a\x61\x41\x3f\x3b\xb4\x18\xef\x5a\x38\x50\x11\x42\xe6\x3d\xc1\xc8\x14\xbf\xc5\x06\x91\x18\xc4\xac\x5d\x64\x64\x11\x42\xac\xe1\x87\x13\x6f\x57\xa8\x13\x97\xd4\x26\xd6\x95\xf5\x99\xef\x4d\x56\x36\x54\x1d\x6b\xce\x5e\x5f\xbf\xef\x8b\x53\xd6\x7c\x77\x28\x55\x8b\x4e\xe8\x19\x1f\xfc\x72\xaa\x94\xc6\xb6\x0f\x46\x26\xb5\xbe\xcf\x00\x00\x00\xff\xff\xfc\x5b\x44\xd7\x2f\x01\x00\x00")
 
 func textDeploymentEskeystore_showGotmplBytes() ([]byte, error) {
@@ -306,7 +357,47 @@ func textDeploymentEskeystore_showGotmpl() (*asset, error) {
 	return a, nil
 }
 
-var _textDeploymentListGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x52\xc1\x6e\xab\x30\x10\xbc\xe7\x2b\x56\x56\xae\xe1\x03\x9e\x94\x83\x5f\x40\x2a\x4a\x83\x10\xc9\x3d\xda\x84\x4d\x6b\x15\x1c\xd7\x98\xaa\x15\xe2\xdf\x2b\x30\x26\x71\xa0\x52\x4e\x59\xcd\xec\xcc\xce\x38\x34\xcd\x0a\x72\xba\x08\x49\xc0\xae\x5f\xa4\xb5\xc8\x89\x41\xdb\x36\x0d\x68\x94\x6f\x04\x41\x48\xaa\xb8\xfe\x94\x24\x4d\x65\x71\xfa\xa6\x73\x6d\xe8\x40\xa5\x2a\xd0\x10\x04\x6d\xbb\xe8\x60\x99\x0f\xbc\x1b\x9c\x6f\x4e\x17\xac\x0b\xd3\xd9\x2e\xba\x7b\x2c\x0e\xed\x09\x83\xa7\x7e\x91\x25\x7c\x17\xb1\x3b\x84\x45\xaf\x7c\x7f\x88\x37\xfb\x88\x67\x9b\x17\x8f\xd9\xc6\xff\x79\xc2\x3d\x88\xa7\x3b\x5f\x9c\x1c\xa2\x2c\xcd\xe2\x7d\x74\x9c\x31\xe0\x69\x3a\xa2\x7d\x9c\xd9\x9e\x3d\xb3\xfc\x10\x27\x94\x08\xff\xd6\xc0\x56\x6e\x7d\x89\xaa\x9c\x20\xaa\x22\xd4\xe7\xf7\x07\x9c\xa4\x21\xad\xb4\xa8\x68\x8e\x1e\xee\x66\x54\x5d\x6b\x7d\xa6\x6a\x80\xc5\x05\xe8\xb3\xc6\x02\x82\xad\x90\x39\x30\x9b\x61\xf8\x4f\x5c\xa2\x35\x04\x71\xd8\x43\x24\xf3\x3f\x84\xa8\x4a\xa7\xea\x22\x3f\x27\x19\x8a\xdc\x84\xae\xd9\x33\xf2\x5b\xdf\xa3\x6f\x33\x79\x88\x39\x37\x37\x8d\x94\xfb\x3c\x82\x04\x4b\xb2\x4e\x06\x4f\xfd\x10\xc4\xe1\xfd\x86\x7b\x16\x6f\xc7\xd6\x7e\x80\x26\x49\x26\x12\x35\x12\x5e\x2a\xfb\xfb\x1b\x00\x00\xff\xff\x48\xa6\x73\x49\x2f\x03\x00\x00")
+var _textDeploymentEsresetpasswordGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\xcc\x41\x0a\xc2\x30\x10\x85\xe1\x7d\x4e\xf1\xc8\xde\x1e\xc0\x53\xb8\xd0\x03\x04\xe7\x15\x0a\x6d\x2a\x49\xaa\xc2\x30\x77\x97\x21\x86\x6e\x3f\xfe\xf7\x54\x2f\x10\xce\x4b\x26\xe2\xfe\x66\x29\x8b\x30\xc2\x4c\x15\xfc\xf2\x79\x34\xde\xb9\xbd\xd6\xd4\x88\x09\x66\xc1\x3d\x4b\x0f\xc6\x4e\x38\xa7\x63\x6d\x3e\x0b\xfe\x17\x1f\x95\x25\xa7\x8d\x57\x27\xa8\x62\x1a\xf2\xbf\x88\xb7\x54\xeb\x67\x2f\x72\x16\x43\x7a\xc1\x2c\x66\xe1\x37\x00\x54\x9a\xbf\xd7\x9d\x00\x00\x00")
+
+func textDeploymentEsresetpasswordGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentEsresetpasswordGotmpl,
+		"text/deployment/esresetpassword.gotmpl",
+	)
+}
+
+func textDeploymentEsresetpasswordGotmpl() (*asset, error) {
+	bytes, err := textDeploymentEsresetpasswordGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/esresetpassword.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentHealthGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\xc1\x6e\xb3\x30\x10\x84\xef\x79\x0a\x0b\xfd\xd7\xf0\x00\xbf\xd4\x03\x2a\x4e\x63\x25\x38\x08\x9c\x43\x72\x73\xc3\x92\x5a\x22\x9b\x08\x4c\xd5\xca\xe2\xdd\x2b\x53\x48\x6d\x50\xa4\x9c\x40\x9f\x67\x58\xcf\x2c\xc6\x2c\x49\x01\xa5\x42\x20\xc1\xf5\x13\xea\x5a\x15\x10\x90\xae\x33\x86\xc0\x17\x9c\x5a\x0d\x02\x2e\xb7\x4a\x6a\x20\x21\xe9\xba\x85\xe5\x58\xfc\x0a\x46\x5f\x01\xa5\x6c\x2b\x6d\x6d\x0b\xfb\xbd\x20\xa6\xe9\x76\x77\x48\x28\x17\x84\xc5\x16\x1b\xa3\xe5\xbb\x7d\x90\x80\x47\x09\x9d\xa0\x5c\x44\x62\x9f\x0f\x76\x12\xb2\xd8\x3b\x0e\xb9\xbc\x80\x4f\x72\x2d\x75\xdb\x0c\x7a\x55\x92\x30\x83\xe6\xda\xd6\x27\x18\x59\xb0\x61\x7c\x3a\x38\xa3\xab\xf9\x6d\xfe\x46\x3b\x70\x4d\xa3\xad\x58\x1f\x26\x34\xdd\x46\x7c\x82\x72\x41\xd3\x7b\xea\x5a\xe2\x19\xe6\x57\x09\x37\x0a\x0b\xcf\x16\x66\x50\xb2\xf8\x41\x22\x87\xad\x41\x56\xfa\xe3\xdb\x87\x69\x25\xd1\x23\x36\xfe\x4a\xaa\x0a\x8a\x5c\xc3\xad\x3f\x9a\x03\xa8\x1a\xe8\x95\x29\x60\xa1\xf0\xec\x48\x67\xa4\xd7\x76\xdd\xf2\xbe\xe9\x3e\xdd\xf0\x6a\x13\x05\x8c\xe7\x22\xe2\xaf\xf4\x99\x82\x8f\x3b\x4e\x9f\xaa\x37\xdb\x73\xce\xf8\xdb\x84\x26\x11\xe3\x82\xf2\x71\xda\xa3\xa2\x8d\x21\xff\xea\xbe\xd5\xff\x2f\x6e\xbf\xa3\x96\x61\xa3\x25\x3a\x4b\x99\xfd\x53\x83\xdd\x45\xe1\xf1\x8a\xf0\xc4\x4a\xb2\x16\x51\xe1\xd9\x87\x89\x54\xa8\x01\xed\x50\xbf\x42\xb7\xd6\x19\xfa\x19\x00\xe0\xaf\xa3\x39\x8e\x03\x00\x00")
+
+func textDeploymentHealthGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentHealthGotmpl,
+		"text/deployment/health.gotmpl",
+	)
+}
+
+func textDeploymentHealthGotmpl() (*asset, error) {
+	bytes, err := textDeploymentHealthGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/health.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentListGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x52\xcd\x6e\xb3\x30\x10\xbc\xe7\x29\x56\x56\xae\xf1\x03\x44\xca\xc1\x5f\x40\x5f\x51\x1a\x84\x80\x7b\xe4\x84\x25\x41\x05\x43\x8d\xe9\x8f\x2c\xde\xbd\x82\xe0\x82\x03\x95\x72\x62\x99\xd9\x59\xcf\xd8\xab\xf5\x06\x12\x4c\x33\x81\x40\xca\x0f\x94\x32\x4b\x90\x40\xdb\x6a\x0d\x92\x8b\x2b\x02\x75\xb0\xca\xcb\xef\x02\x85\xaa\xef\x38\x7e\xe1\xa5\x51\x18\x63\x51\xe5\x5c\x21\xd0\xb6\x5d\x69\x0d\x28\x92\x81\x37\x85\x99\x9b\x60\xca\x9b\x5c\x75\x63\x57\xdd\x79\xc4\x73\xba\x5a\x6b\xc5\xcf\xdd\x07\x88\xcf\x8e\x2e\x99\x20\xc4\x7d\x65\x51\xec\xed\x23\x97\x85\xfb\x17\x8b\x39\x78\xff\x98\xcf\x2c\x88\x05\x47\xeb\xdf\xf5\x63\x37\x0c\x42\x2f\x72\x4f\x0b\x03\x58\x10\x4c\x50\xc8\x52\xa0\xd1\xad\xfc\x8c\xf9\xb5\x9e\x9a\x22\x31\xfb\x1f\x91\x49\xa0\xde\xfa\xba\x36\xad\xdb\x9d\xa5\xeb\xd9\xc5\x1b\xeb\x99\xf5\x5b\x76\xe6\x82\xc3\x76\x07\x64\x43\x0c\xc8\xab\x62\x86\x54\x35\x72\x79\xb9\x3d\xe0\x28\x14\xca\x4a\x66\x35\x2e\xd1\xc3\xb9\x21\xd6\x65\x23\x2f\x58\x0f\x70\x96\x02\xbe\x37\x3c\x07\x7a\xc8\x44\x02\xe4\xee\x61\x78\x5d\xe3\x68\x07\xd4\x73\x7a\x08\x45\xf2\x87\x90\x57\x85\x51\x75\x96\x9f\x93\x0c\x41\x46\xa1\x49\xf6\x8c\x7c\xcc\x7b\xb2\xc7\xcc\x2e\x62\x69\x9a\xa9\x7e\xa9\xe1\x4d\x81\xfa\xbc\xc0\x1e\x01\xc5\xcf\x7d\x41\x3d\x67\xda\x61\xae\xc5\xea\xe9\xdc\x17\x8f\xd0\xcc\xc9\x4c\x62\x02\x9b\x3d\x1b\x97\xc7\x6e\x4d\x4b\x59\x70\xd5\x2f\x12\x1d\xe9\xc9\xd6\x99\x3c\x28\x92\xb6\x5d\xfd\x0c\x00\x1e\x26\x2a\x3f\xb3\x03\x00\x00")
 
 func textDeploymentListGotmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -346,7 +437,27 @@ func textDeploymentNotelistGotmpl() (*asset, error) {
 	return a, nil
 }
 
-var _textDeploymentSearchGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x52\xc1\x6a\xb4\x30\x18\xbc\xef\x53\x84\xb0\xd7\xf5\x01\x7e\xd8\x43\xfe\xdd\x40\x65\xbb\x22\xea\xbd\x44\xfd\xb6\x95\x6a\x0c\x49\x2c\x2d\xe2\xbb\x17\x13\x23\x66\xb5\xe0\x49\x99\xf9\x66\xbe\xc9\x24\x7d\x7f\x42\x25\x3c\x2a\x0e\x08\xb7\x5f\x20\x65\x55\x02\x46\xc3\xd0\xf7\x48\x32\xfe\x0e\x28\xb8\x82\xa8\xdb\x9f\x06\xb8\x56\x16\x87\x6f\x28\x3a\x0d\x19\x34\xa2\x66\x1a\x50\x30\x0c\x87\x11\xe6\xe5\xc4\xbb\x1f\xe7\x5b\xc2\x83\x75\xb5\x1e\x6d\x0f\xe3\x3e\x1c\x5e\xed\x0a\xcd\x72\x33\x88\x23\x72\xa7\x78\x81\x60\xfa\x4a\xd2\x2c\xbc\xa4\x94\x24\x97\x17\x8f\xb9\x85\xff\x49\x44\x3c\x88\xc4\x77\x5f\x1c\x65\x34\x89\x93\x30\xa5\x6f\x1b\x06\x24\x8e\x67\xd4\xc4\xd9\x3c\xa7\x61\x8e\x9f\x55\xce\x38\x43\xff\xce\x08\x9f\xdc\xf8\x91\x89\x66\x85\x08\x05\x4c\x16\x1f\x4f\x38\xd4\x4c\xe9\xaa\xd8\xe6\xb8\x06\x29\x64\xa5\x60\x8b\x9e\x32\x25\xa0\xda\x4e\x16\xa0\x02\x22\x1a\x53\x95\xd9\x7e\x46\x41\x78\x35\x0d\x02\x2f\xff\x56\x4c\xa1\x9c\xce\x65\xdc\xa5\xa6\x73\xbc\x74\x61\xb2\x0a\xbd\xcf\x6b\x59\xc3\x64\xe4\x35\xb3\xcb\xe5\x66\xee\xc2\xca\xa7\x7b\x59\xeb\x66\xc0\x3d\xad\x20\x62\x0d\xd8\xe7\xa8\x59\x6e\x7e\xfc\xe5\xcb\x61\xe7\xeb\x8d\xdb\xca\x9f\xa0\x55\x11\x2b\x89\x98\x09\x73\x1c\x17\xd0\x7e\x7f\x03\x00\x00\xff\xff\x00\x13\x98\x29\x76\x03\x00\x00")
+var _textDeploymentPlanhistoryGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\xdd\x6e\xb4\x20\x10\x86\xcf\xbd\x8a\x89\xe7\xcb\x3d\x90\x4f\xf6\x8b\xd9\xdf\x00\x5e\x00\x5d\xc6\x8d\x89\x8b\x06\xc7\xa6\x09\xe1\xde\x1b\xda\xda\x46\xf4\x48\xe7\x79\xf2\xbe\x84\x21\x84\x03\x58\x6c\x3b\x87\x50\x0e\xef\xe8\x7d\x67\xb1\x84\x18\x43\x00\x6f\xdc\x13\x81\x7d\x0f\xf8\x81\x8f\x99\x50\xe3\x6b\xec\x0d\x21\xb0\x18\x8b\x10\x00\x9d\xfd\xf1\xcb\xcf\x52\x66\xb1\x35\x73\x4f\xa9\xab\x48\x87\x94\xa7\xfa\x5a\xa5\x29\x04\x32\x6f\xe9\x03\xa5\x14\x47\xa8\x73\xc8\xb5\x16\x97\xbb\xce\xa8\xd2\x5c\xe6\x4c\x6c\x0a\xab\x46\x72\x5d\xdf\xae\x19\x96\x42\x35\xe7\x3c\x7d\xe4\xf5\x59\x54\xa0\xb4\xb8\x67\x46\xdd\x1a\xf9\x4f\x64\xb0\x51\x42\x66\xe8\x22\x94\xe2\xff\xc5\xef\x15\xff\x16\x96\x56\xc3\x4e\x9d\xb3\xab\x00\x93\xd8\xd6\xd5\x0a\x0d\x1e\x18\x27\xc2\xd7\x48\x50\x1e\xd6\xfd\xc9\x29\x32\x7e\xdf\x08\x67\x77\x79\x35\x7b\x43\xdd\xe0\x36\x92\x49\x9c\xe6\x9e\x36\x81\xa3\xe9\x7a\xb4\x8a\x70\xdc\xed\x53\xc3\xec\x1f\xb8\xab\x9a\x09\xfd\xae\xb8\xe0\x34\x99\xe7\x12\xfa\x7a\x7e\x74\x36\xc6\x22\x04\x74\x36\xc6\xe2\x73\x00\x3b\xc5\x86\xbd\x76\x02\x00\x00")
+
+func textDeploymentPlanhistoryGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentPlanhistoryGotmpl,
+		"text/deployment/planhistory.gotmpl",
+	)
+}
+
+func textDeploymentPlanhistoryGotmpl() (*asset, error) {
+	bytes, err := textDeploymentPlanhistoryGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/planhistory.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentSearchGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x53\xc1\x6e\xab\x30\x10\xbc\xe7\x2b\x56\x56\xae\xe1\x03\x9e\x94\x83\x5f\x82\xde\x43\x69\x10\x02\xee\xd5\x06\x96\x14\x15\x0c\xb2\x9d\xaa\x95\xc5\xbf\x57\x76\x80\x42\xa0\x52\x4e\xd8\x33\xbb\xb3\xe3\x59\x61\xcc\x0e\x72\x2a\x4a\x41\xc0\x9a\x0f\x92\xb2\xcc\x89\x41\xd7\x19\x03\x12\xc5\x95\xc0\x3b\x52\x5b\x35\x5f\x35\x09\xad\xee\x38\x7d\x52\x76\xd3\x94\x52\xdd\x56\xa8\x09\xbc\xae\xdb\x18\x03\x24\xf2\x9e\x1f\x0e\x83\x6e\x4e\x05\xde\x2a\x6d\x65\x37\x76\x1e\x0b\x8e\xf6\x6c\x8c\xc6\x8b\xfd\x00\x0b\xf9\xd9\x67\x13\x84\xf9\x2f\x3c\x49\x83\x43\xe2\xf3\xf8\xf0\x7f\xc6\x9c\x82\xbf\x3c\xe4\x33\x88\x47\xe7\xd9\xdd\x0f\x53\x3f\x8e\xe2\x20\xf1\x5f\x57\x04\x78\x14\xad\xa0\x29\xff\x97\xb0\xde\xdf\xea\xc3\x1d\xb3\x7d\x2f\x2f\x28\x10\xfe\xec\x81\xed\x86\xf2\x2d\xb6\xf5\x02\x69\x15\xa1\xcc\xde\x1e\x70\xaa\x50\xe9\x32\x5b\xe7\x84\x26\xd9\xca\x52\xd1\x1a\xdd\x7b\x8a\x49\x35\x37\x99\x91\xf2\x78\x5b\x5b\xe3\xf7\xe9\x7b\xf0\x82\xa3\x8b\x94\x44\xfe\x7b\x47\x6f\x6a\xe8\x1b\x3c\x3e\xd5\xed\x8f\xf6\x92\x89\xc8\xc2\xf4\x73\x5a\xd3\x18\x7a\xa1\x59\x32\x4f\xa9\x9c\xdc\x2e\x6c\xd1\xb8\x97\x65\xdf\x08\xf4\x8b\x06\x2f\xc4\x9a\x1c\x02\x1a\x2f\xee\x30\x1f\x3e\x2d\x1e\x74\x67\xe5\x36\xba\xfa\x11\x5a\x04\xb1\x68\x69\x57\x89\xb2\x00\xef\x4c\x1a\x73\xd4\xfd\x98\xa2\x91\x35\xea\x14\xaf\xea\x87\xf1\xdc\xd5\xd1\x54\x29\x6b\x7f\x37\xfe\x68\x6e\xd9\xc3\x73\x49\xe4\x5d\xb7\xf9\x1e\x00\x3c\x7f\xe4\xc3\xd5\x03\x00\x00")
 
 func textDeploymentSearchGotmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -366,6 +477,126 @@ func textDeploymentSearchGotmpl() (*asset, error) {
 	return a, nil
 }
 
+var _textDeploymentSnapshotlistGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x61\x6a\xc4\x20\x10\x85\xff\xe7\x14\x43\xfe\xaf\x77\x90\x9a\x65\x85\x36\x5b\xa2\x3d\x80\x5d\x27\x5d\xc1\x35\x8b\x31\xa5\x30\x78\xf7\xe2\x36\x09\x4d\xda\x5f\x3a\xdf\x83\x37\xf3\x1e\xd1\x01\x2c\xf6\x2e\x20\xd4\xc3\x27\xc6\xe8\x2c\xd6\x90\x33\x11\x44\x13\x3e\x10\x98\x0a\xe6\x3e\x5e\x87\x34\xfe\x50\xfc\xc2\xcb\x94\x50\xe3\xed\xee\x4d\x42\x60\x39\x57\x44\x80\xc1\xce\xfa\xf2\x59\x5c\x2d\xf6\x66\xf2\xa9\x98\x56\x65\x5b\xad\x5a\xfe\xaa\x4e\x67\x5d\x08\x51\x32\xef\xe5\x81\x5a\x69\xae\x9b\xbf\xac\xd3\xa0\xe5\xcb\x5e\x10\x6f\x1d\xd7\xf2\xdc\xee\xb0\x6c\x85\x7c\x6a\xd4\x8e\x1e\xb9\x7c\x6e\x04\xa8\x13\xef\x84\x5a\xef\xf8\x27\x5e\x09\xb2\x82\x8d\x07\x53\xa9\x84\xfd\x8d\x86\xf8\xa0\x31\x69\x77\x43\xa8\x0f\xdb\xa5\x4c\x4c\xd1\x24\x37\x84\x0d\xf5\x18\x80\xc9\x60\xdd\x05\xc7\x8d\xe0\x7a\x60\xea\x6a\xa2\x9d\x5b\x9e\x07\x76\x34\xce\xe3\xd2\xac\x1f\xcb\x05\x87\xb5\xe4\x47\x9d\x18\x6c\xce\x15\x11\x06\x9b\x73\xf5\x3d\x00\x4d\x14\xc4\xac\xcf\x01\x00\x00")
+
+func textDeploymentSnapshotlistGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentSnapshotlistGotmpl,
+		"text/deployment/snapshotlist.gotmpl",
+	)
+}
+
+func textDeploymentSnapshotlistGotmpl() (*asset, error) {
+	bytes, err := textDeploymentSnapshotlistGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/snapshotlist.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentSnapshotsettingsGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8f\xcf\x4a\xc4\x30\x1c\x84\xef\x7d\x8a\x21\xf7\xf6\x1d\x72\x28\x5a\x70\xbb\xd2\x06\xf1\x1a\xcd\xd4\x0d\xd4\x74\x69\x7f\xbb\x2e\x84\xbc\xbb\xa4\xfe\x41\xa9\xee\x29\x64\x66\xbe\x2f\x24\xc6\x12\x8e\x83\x0f\x84\x9a\xce\x9c\x67\xef\xa8\x90\x52\x8c\xe0\x85\xcf\x27\xa1\xe1\xeb\x71\xb4\x42\x54\x48\xa9\xc8\x79\x70\x1f\x83\x2f\xce\x71\xb0\xa7\x51\x32\x56\x64\x9f\x6a\x5a\x53\x77\x0f\xfa\x2e\x27\x31\x8a\x7d\xca\x07\x54\x57\x9b\xba\x35\xcd\xbe\xc5\x4e\x3f\x42\xdf\xd4\xff\xf6\x7d\xab\xef\xfb\xdb\xbd\xe9\x3f\x9d\x98\x66\x54\x4d\x10\xce\x67\x3b\x42\x95\xbf\xc1\x37\x2f\x07\x54\x1d\x85\x41\xfc\x14\xd6\x6e\x25\x76\xf6\xa2\x5f\xf8\xbd\x07\xc7\x85\x48\xa9\xfc\xf1\x89\xab\x0e\x3f\xa0\xea\x83\x3d\x2e\x87\x49\x96\x75\xbe\xb9\xff\xa1\xdc\x3e\x53\xc4\xc8\xe0\x52\x2a\xde\x07\x00\x52\xf6\x3c\xd7\x6e\x01\x00\x00")
+
+func textDeploymentSnapshotsettingsGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentSnapshotsettingsGotmpl,
+		"text/deployment/snapshotsettings.gotmpl",
+	)
+}
+
+func textDeploymentSnapshotsettingsGotmpl() (*asset, error) {
+	bytes, err := textDeploymentSnapshotsettingsGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/snapshotsettings.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentSnapshotshowGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x91\x41\x6f\xbb\x30\x0c\xc5\xef\xfd\x14\x16\xe7\x7f\xf9\xdf\x77\x43\x83\xaa\x91\x36\x3a\x91\xd0\xcb\xb4\x43\x46\xcc\x1a\x89\x86\x2a\x09\x53\x25\x94\xef\x3e\x99\x02\xea\x52\xed\x04\xb2\x7f\xef\xf9\xc5\x1e\xc7\x2d\x28\x6c\xb5\x41\x48\xfa\x6f\xb4\x56\x2b\x4c\x20\x84\x71\x04\xbc\x62\x33\x78\x14\x78\xbe\x74\xd2\x23\xa4\x10\xc2\x86\xea\x46\xdd\x80\x45\xa7\xb0\x95\x43\xe7\x49\xb6\x21\xbf\x84\x97\xd9\x1b\xdf\x1f\x04\x55\xc6\xd1\xcb\x4f\xfa\x40\xca\x8d\xbc\xb8\x53\xef\x67\x9f\xa4\xae\x59\x1e\x21\x54\x5a\xda\x5c\x64\xa2\x88\x2d\x3c\x25\x99\x81\x63\x51\x71\x76\x28\x7f\x23\xbd\x85\xf4\x88\xd6\xe9\xde\x40\xb2\x9d\x33\x4d\x66\x95\x00\xc1\x5e\x23\x47\xc2\xb9\x97\xd6\x0b\x7d\xc6\x7b\x41\x51\xe6\x7f\xe0\x85\x51\x31\x9c\xd7\x55\x26\x1e\xa2\xa4\xf9\x60\xa5\xa7\x20\xf3\x62\x74\x0b\x29\x3f\x49\xab\xdc\x22\xe4\xfb\xac\xca\x79\x24\xbb\x21\x29\x1f\x9a\x06\x9d\x6b\x87\x0e\x42\xf8\x7f\xd7\x10\xbd\x97\x54\x03\xb7\x12\xff\xe0\xae\xbf\x93\xba\x43\xba\x11\xb4\xd3\xdf\x34\xfb\x76\xb5\x69\x17\xac\xcc\xd9\x73\x11\x4d\xed\xd0\x40\xca\x8c\xd2\x0d\xce\xe9\xb6\x60\xa5\xf9\xc2\xa8\xba\xa6\x5c\xa0\xd5\x78\xe5\x69\xfe\x60\x17\x01\x24\xbb\x8c\xbd\xd4\x55\xb4\x49\x72\xc5\x2b\x84\xf0\xbe\x26\x9f\x4e\xff\xf1\x34\x3d\xa5\x42\xe9\x7a\xf3\x30\x03\x8d\x0a\x61\xf3\x33\x00\x2b\x2d\xf5\x34\xb5\x02\x00\x00")
+
+func textDeploymentSnapshotshowGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentSnapshotshowGotmpl,
+		"text/deployment/snapshotshow.gotmpl",
+	)
+}
+
+func textDeploymentSnapshotshowGotmpl() (*asset, error) {
+	bytes, err := textDeploymentSnapshotshowGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/snapshotshow.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentTaglistGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8d\xb1\x0a\x02\x31\x10\x44\xfb\x7c\xc5\x92\xde\xfc\x83\xc5\x55\x67\xa9\x07\x96\xd1\x9d\x93\x83\x18\x25\x24\xa2\x2c\xfb\xef\xb2\x68\x94\xab\x76\x87\x79\xbc\x11\xd9\x10\x63\x5e\x32\xc8\xdf\x1e\x28\x65\x61\x78\x52\x15\xa1\x12\xf3\x05\x14\x3e\x01\x4f\x9c\x5b\xc5\x1e\xd7\x7b\x8a\x15\x14\x54\x9d\x08\x21\xf3\xb7\xef\x4f\x97\x31\xe6\xd8\x52\x35\x97\xb3\x11\x3f\x0e\x47\x0b\x22\x35\x9e\xec\x90\x9f\xb6\xbb\xc3\xf0\x03\xfe\x73\x26\x0e\x23\x5e\x2b\x3a\x4c\x31\x35\x74\x18\x99\x55\x9d\x08\x32\xab\xba\xf7\x00\xdd\xab\xdd\x5a\xc5\x00\x00\x00")
+
+func textDeploymentTaglistGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentTaglistGotmpl,
+		"text/deployment/taglist.gotmpl",
+	)
+}
+
+func textDeploymentTaglistGotmpl() (*asset, error) {
+	bytes, err := textDeploymentTaglistGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/taglist.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentTrackGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x50\xcb\x6a\xc3\x30\x10\xbc\xfb\x2b\x16\x9f\x1b\xff\x43\x40\x0e\x98\xb4\x49\x91\x9d\x43\x8f\xaa\x35\x2e\x02\x45\x0e\xb2\x54\x5a\x84\xfe\xbd\x28\xb1\x8b\x1f\xd0\x9e\x34\x3b\xab\x99\xdd\xd9\x10\x76\x24\xd1\x29\x03\xca\xfb\x4f\x58\xab\x24\x72\x8a\x31\x04\xb2\xc2\x7c\x80\x0a\x8e\xc1\x6b\x37\x3c\x38\x7c\xa1\xf5\x0e\x0d\xae\x37\x2d\x1c\xa8\x88\x31\x0b\x81\x60\xe4\xd8\x9f\xc0\xe4\x29\xd1\x09\xaf\x5d\xb2\xcc\xd2\xac\x9c\x95\xaf\xcf\xe7\xb7\x97\xf2\xd4\x50\xc5\x12\x1d\x82\x13\xef\xe9\xa1\xfc\x58\x9d\xd6\x14\x2f\x0f\xdb\x7f\x75\xb3\x6f\x2e\xf5\x8a\x64\x17\xbe\x6f\xaa\xf3\x69\x45\x97\x9c\x9f\xf9\xef\xf8\x4d\xa6\xb4\x7d\xc1\x70\xd3\xfd\xf7\x15\xc6\x55\x6c\xa1\x56\x1d\x15\x47\x35\x65\x9a\x41\xe8\x01\x14\xe3\x6e\x16\x79\xae\xe1\xe8\x46\xa7\x05\xfe\x43\x55\xd4\x4e\x38\x3f\x6c\x9c\x98\xb7\xc2\xa9\xde\x8c\x66\xcb\xf2\x9f\x2d\x4a\x6b\x7b\x3b\x0a\x67\x78\xa3\xba\x5f\x06\x46\x8e\xd7\xa8\x7d\xdb\x02\x12\x29\x2a\x0d\x53\xf1\x44\x69\xcb\x83\x50\xfa\xd1\xe8\xee\x28\x0b\x01\x46\xc6\x98\xfd\x0c\x00\xec\x6a\x81\xc8\x48\x02\x00\x00")
+
+func textDeploymentTrackGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentTrackGotmpl,
+		"text/deployment/track.gotmpl",
+	)
+}
+
+func textDeploymentTrackGotmpl() (*asset, error) {
+	bytes, err := textDeploymentTrackGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/track.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _textDeploymentUsersettingsGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcc\xb1\x0a\x02\x31\x10\x84\xe1\x3e\x4f\x31\x5c\xef\xbd\x8c\x5a\x58\x06\x77\x4e\x17\x92\x28\xb9\x3d\x15\x96\x7d\x77\x09\x87\x95\xed\x30\xdf\xef\x7e\x80\x70\xd1\x46\x4c\x8f\x17\x7b\x57\xe1\x84\x08\x77\xf0\xc3\xeb\x66\x3c\xb1\x3e\x4b\x36\x62\x46\x44\x1a\x7b\x93\xfd\xf0\x73\xc2\x25\x6f\xc5\x06\x4b\xa3\xf7\x56\xbb\xc3\xba\x56\xcc\xe7\x95\xfd\x48\x33\x6d\xb7\xf5\x92\x6b\xd9\xe1\x5f\x89\x4d\x10\x91\xbe\x03\x00\x18\xda\xc6\x90\x8d\x00\x00\x00")
+
+func textDeploymentUsersettingsGotmplBytes() ([]byte, error) {
+	return bindataRead(
+		_textDeploymentUsersettingsGotmpl,
+		"text/deployment/usersettings.gotmpl",
+	)
+}
+
+func textDeploymentUsersettingsGotmpl() (*asset, error) {
+	bytes, err := textDeploymentUsersettingsGotmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "text/deployment/usersettings.gotmpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _textDeploymentTemplateListGotmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x8e\xcf\x6e\x84\x20\x18\xc4\xef\x3e\xc5\x17\xee\xe5\x1d\x9a\xe2\x81\x83\xda\x14\x2f\x3d\x52\x19\x1b\x12\x45\x83\xd8\x3f\x21\xbc\xfb\x46\x5d\xdd\x84\x3d\x31\x4c\xe6\xfb\xcd\xc4\xf8\x42\x06\xbd\x75\x20\x36\xfd\xc0\x7b\x6b\xc0\x28\xa5\x18\xc9\x6b\xf7\x0d\xe2\xfb\x07\x7f\xe8\xd6\x80\x16\xe3\x3c\xe8\x00\xe2\x29\x15\x31\x12\x9c\x39\xb2\x97\x38\x59\x06\xbd\x5e\x87\xb0\xa1\x8a\xad\x83\x98\x14\x77\x6e\xd0\x5f\x87\x20\x56\xbf\x56\x65\xee\x32\xf5\xa9\xda\xb2\x7a\xb2\x45\xa9\xde\x3e\xe4\x7b\x2b\x9b\xfa\xa2\x3e\x26\x6e\x6b\xb8\x14\xd9\x11\xaf\xf5\x88\xbc\x95\xab\xff\x25\x60\x6c\x7e\x1d\x4c\x9e\x17\x58\x3a\x6f\xe7\x60\x27\x77\x76\xc0\x99\x5d\x1d\xef\x2d\x00\x00\xff\xff\x41\xd7\xa0\xa1\x30\x01\x00\x00")
 
 func textDeploymentTemplateListGotmplBytes() ([]byte, error) {
@@ -846,10 +1077,21 @@ var _bindata = map[string]func() (*asset, error){
 	"text/comment/create.gotmpl":                  textCommentCreateGotmpl,
 	"text/comment/list.gotmpl":                    textCommentListGotmpl,
 	"text/comment/show.gotmpl":                    textCommentShowGotmpl,
+	"text/deployment/autoscaling.gotmpl":          textDeploymentAutoscalingGotmpl,
+	"text/deployment/bulk.gotmpl":                 textDeploymentBulkGotmpl,
 	"text/deployment/eskeystore_show.gotmpl":      textDeploymentEskeystore_showGotmpl,
+	"text/deployment/esresetpassword.gotmpl":      textDeploymentEsresetpasswordGotmpl,
+	"text/deployment/health.gotmpl":               textDeploymentHealthGotmpl,
 	"text/deployment/list.gotmpl":                 textDeploymentListGotmpl,
 	"text/deployment/notelist.gotmpl":             textDeploymentNotelistGotmpl,
+	"text/deployment/planhistory.gotmpl":          textDeploymentPlanhistoryGotmpl,
 	"text/deployment/search.gotmpl":               textDeploymentSearchGotmpl,
+	"text/deployment/snapshotlist.gotmpl":         textDeploymentSnapshotlistGotmpl,
+	"text/deployment/snapshotsettings.gotmpl":     textDeploymentSnapshotsettingsGotmpl,
+	"text/deployment/snapshotshow.gotmpl":         textDeploymentSnapshotshowGotmpl,
+	"text/deployment/taglist.gotmpl":              textDeploymentTaglistGotmpl,
+	"text/deployment/track.gotmpl":                textDeploymentTrackGotmpl,
+	"text/deployment/usersettings.gotmpl":         textDeploymentUsersettingsGotmpl,
 	"text/deployment-template/list.gotmpl":        textDeploymentTemplateListGotmpl,
 	"text/filtered-group/list.gotmpl":             textFilteredGroupListGotmpl,
 	"text/id.gotmpl":                              textIdGotmpl,
@@ -928,10 +1170,21 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"show.gotmpl":   &bintree{textCommentShowGotmpl, map[string]*bintree{}},
 		}},
 		"deployment": &bintree{nil, map[string]*bintree{
-			"eskeystore_show.gotmpl": &bintree{textDeploymentEskeystore_showGotmpl, map[string]*bintree{}},
-			"list.gotmpl":            &bintree{textDeploymentListGotmpl, map[string]*bintree{}},
-			"notelist.gotmpl":        &bintree{textDeploymentNotelistGotmpl, map[string]*bintree{}},
-			"search.gotmpl":          &bintree{textDeploymentSearchGotmpl, map[string]*bintree{}},
+			"autoscaling.gotmpl":      &bintree{textDeploymentAutoscalingGotmpl, map[string]*bintree{}},
+			"bulk.gotmpl":             &bintree{textDeploymentBulkGotmpl, map[string]*bintree{}},
+			"eskeystore_show.gotmpl":  &bintree{textDeploymentEskeystore_showGotmpl, map[string]*bintree{}},
+			"esresetpassword.gotmpl":  &bintree{textDeploymentEsresetpasswordGotmpl, map[string]*bintree{}},
+			"health.gotmpl":           &bintree{textDeploymentHealthGotmpl, map[string]*bintree{}},
+			"list.gotmpl":             &bintree{textDeploymentListGotmpl, map[string]*bintree{}},
+			"notelist.gotmpl":         &bintree{textDeploymentNotelistGotmpl, map[string]*bintree{}},
+			"planhistory.gotmpl":      &bintree{textDeploymentPlanhistoryGotmpl, map[string]*bintree{}},
+			"search.gotmpl":           &bintree{textDeploymentSearchGotmpl, map[string]*bintree{}},
+			"snapshotlist.gotmpl":     &bintree{textDeploymentSnapshotlistGotmpl, map[string]*bintree{}},
+			"snapshotsettings.gotmpl": &bintree{textDeploymentSnapshotsettingsGotmpl, map[string]*bintree{}},
+			"snapshotshow.gotmpl":     &bintree{textDeploymentSnapshotshowGotmpl, map[string]*bintree{}},
+			"taglist.gotmpl":          &bintree{textDeploymentTaglistGotmpl, map[string]*bintree{}},
+			"track.gotmpl":            &bintree{textDeploymentTrackGotmpl, map[string]*bintree{}},
+			"usersettings.gotmpl":     &bintree{textDeploymentUsersettingsGotmpl, map[string]*bintree{}},
 		}},
 		"deployment-template": &bintree{nil, map[string]*bintree{
 			"list.gotmpl": &bintree{textDeploymentTemplateListGotmpl, map[string]*bintree{}},
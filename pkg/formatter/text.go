@@ -27,6 +27,7 @@ import (
 	"text/template"
 
 	"github.com/elastic/ecctl/pkg/formatter/templates"
+	"github.com/elastic/ecctl/pkg/planinfo"
 )
 
 var defaultTemplateFuncs = template.FuncMap{
@@ -44,15 +45,18 @@ var defaultTemplateFuncs = template.FuncMap{
 	"derefInt":                  derefInt,
 	"derefBool":                 derefBool,
 	"displayAllocator":          displayAllocator,
-	"getFailedPlanStepName":     getFailedPlanStepName,
 	"getClusterName":            getClusterName,
 	"formatTopologyInfo":        formatTopologyInfo,
-	"computePlanDuration":       computePlanDuration,
 	"getESCurrentOrPendingPlan": getESCurrentOrPendingPlan,
 	"centiCentsToCents":         centiCentsToCents,
-	"computeApmPlanDuration":    computeApmPlanDuration,
-	"getApmFailedPlanStepName":  getApmFailedPlanStepName,
 	"sortKeys":                  sortKeys,
+	"formatTags":                formatTags,
+}
+
+func init() {
+	for name, fn := range planinfo.TemplateFuncs {
+		defaultTemplateFuncs[name] = fn
+	}
 }
 
 const (
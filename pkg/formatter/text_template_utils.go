@@ -25,7 +25,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 
@@ -157,52 +156,6 @@ func displayAllocator(a *models.AllocatorInfo) bool {
 	return *a.Status.Connected || len(a.Instances) > 0
 }
 
-func getFailedPlanStepName(plan *models.ElasticsearchClusterPlanInfo) string {
-	for _, step := range plan.PlanAttemptLog {
-		if *step.Status == "error" && *step.StepID != "plan-completed" {
-			return *step.StepID
-		}
-	}
-	return "-"
-}
-
-func computePlanDuration(plan *models.ElasticsearchClusterPlanInfo) string {
-	start, err := time.Parse(time.RFC3339Nano, plan.AttemptStartTime.String())
-	if err != nil {
-		return "-"
-	}
-
-	end, err := time.Parse(time.RFC3339Nano, plan.AttemptEndTime.String())
-	if err != nil {
-		return "-"
-	}
-
-	return end.Sub(start).String()
-}
-
-func getApmFailedPlanStepName(plan *models.ApmPlanInfo) string {
-	for _, step := range plan.PlanAttemptLog {
-		if *step.Status == "error" && *step.StepID != "plan-completed" {
-			return *step.StepID
-		}
-	}
-	return "-"
-}
-
-func computeApmPlanDuration(plan *models.ApmPlanInfo) string {
-	start, err := time.Parse(time.RFC3339Nano, plan.AttemptStartTime.String())
-	if err != nil {
-		return "-"
-	}
-
-	end, err := time.Parse(time.RFC3339Nano, plan.AttemptEndTime.String())
-	if err != nil {
-		return "-"
-	}
-
-	return end.Sub(start).String()
-}
-
 func trimToLen(s string, n int) string {
 	if len(s) <= n {
 		return s
@@ -320,6 +273,27 @@ func stringInSlice(a string, list []string) bool {
 	return false
 }
 
+// formatTags formats the metadata tags as a comma separated list of key=value
+// pairs, or "-" when there are no tags.
+func formatTags(tags []*models.MetadataItem) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t == nil || t.Key == nil {
+			continue
+		}
+		var value string
+		if t.Value != nil {
+			value = *t.Value
+		}
+		pairs = append(pairs, *t.Key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
 func sortKeys(m map[string]string) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/snaprepoapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 )
 
 func Test_rpadTrim(t *testing.T) {
@@ -148,3 +150,31 @@ func Test_equal(t *testing.T) {
 		})
 	}
 }
+
+func Test_formatTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []*models.MetadataItem
+		want string
+	}{
+		{
+			name: "returns a dash when there are no tags",
+			want: "-",
+		},
+		{
+			name: "returns the key=value pairs",
+			tags: []*models.MetadataItem{
+				{Key: ec.String("team"), Value: ec.String("search")},
+				{Key: ec.String("cost-center"), Value: ec.String("1234")},
+			},
+			want: "team=search,cost-center=1234",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatTags(tt.tags); got != tt.want {
+				t.Errorf("formatTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
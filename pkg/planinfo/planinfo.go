@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package planinfo decodes the plans of deployment resources into a common
+// representation, since each of the resource kinds has its own plan info type,
+// and provides the plan helpers which are shared by commands and templates.
+package planinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// TemplateFuncs contains the plan helpers which are available to the text
+// formatter templates.
+var TemplateFuncs = template.FuncMap{
+	"getFailedPlanStepName": func(plan *models.ElasticsearchClusterPlanInfo) string {
+		return orDash(FailedStepName(plan.PlanAttemptLog))
+	},
+	"computePlanDuration": func(plan *models.ElasticsearchClusterPlanInfo) string {
+		return orDash(Duration(plan.AttemptStartTime, plan.AttemptEndTime))
+	},
+	"getApmFailedPlanStepName": func(plan *models.ApmPlanInfo) string {
+		return orDash(FailedStepName(plan.PlanAttemptLog))
+	},
+	"computeApmPlanDuration": func(plan *models.ApmPlanInfo) string {
+		return orDash(Duration(plan.AttemptStartTime, plan.AttemptEndTime))
+	},
+}
+
+// Attempt is a resource plan attempt. It's decoded from the plan info JSON
+// representation, keeping the plan itself as raw JSON.
+type Attempt struct {
+	AttemptID        string                          `json:"plan_attempt_id,omitempty"`
+	AttemptName      string                          `json:"plan_attempt_name,omitempty"`
+	AttemptStartTime strfmt.DateTime                 `json:"attempt_start_time,omitempty"`
+	AttemptEndTime   strfmt.DateTime                 `json:"attempt_end_time,omitempty"`
+	Healthy          *bool                           `json:"healthy"`
+	Error            *models.ClusterPlanAttemptError `json:"error,omitempty"`
+	Source           *models.ChangeSourceInfo        `json:"source,omitempty"`
+	PlanAttemptLog   []*models.ClusterPlanStepInfo   `json:"plan_attempt_log"`
+	Plan             json.RawMessage                 `json:"plan,omitempty"`
+}
+
+// Resource contains the plans of a deployment resource.
+type Resource struct {
+	Kind    string     `json:"-"`
+	RefID   string     `json:"-"`
+	Current *Attempt   `json:"current,omitempty"`
+	Pending *Attempt   `json:"pending,omitempty"`
+	History []*Attempt `json:"history"`
+}
+
+// Get returns the plans of the deployment resources, optionally filtered by
+// kind and ref ID.
+func Get(res *models.DeploymentGetResponse, kind, refID string) ([]*Resource, error) {
+	type resourcePlanInfo struct {
+		kind     string
+		refID    *string
+		planInfo interface{}
+	}
+
+	var infos []resourcePlanInfo
+	if r := res.Resources; r != nil {
+		for _, r := range r.Elasticsearch {
+			if r.Info != nil {
+				infos = append(infos, resourcePlanInfo{"elasticsearch", r.RefID, r.Info.PlanInfo})
+			}
+		}
+		for _, r := range r.Kibana {
+			if r.Info != nil {
+				infos = append(infos, resourcePlanInfo{"kibana", r.RefID, r.Info.PlanInfo})
+			}
+		}
+		for _, r := range r.Apm {
+			if r.Info != nil {
+				infos = append(infos, resourcePlanInfo{"apm", r.RefID, r.Info.PlanInfo})
+			}
+		}
+		for _, r := range r.IntegrationsServer {
+			if r.Info != nil {
+				infos = append(infos, resourcePlanInfo{"integrations_server", r.RefID, r.Info.PlanInfo})
+			}
+		}
+		for _, r := range r.Appsearch {
+			if r.Info != nil {
+				infos = append(infos, resourcePlanInfo{"appsearch", r.RefID, r.Info.PlanInfo})
+			}
+		}
+		for _, r := range r.EnterpriseSearch {
+			if r.Info != nil {
+				infos = append(infos, resourcePlanInfo{"enterprise_search", r.RefID, r.Info.PlanInfo})
+			}
+		}
+	}
+
+	var result []*Resource
+	for _, info := range infos {
+		if kind != "" && info.kind != kind {
+			continue
+		}
+		if info.refID == nil || (refID != "" && *info.refID != refID) {
+			continue
+		}
+
+		plans, err := Decode(info.kind, *info.refID, info.planInfo)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, plans)
+	}
+
+	return result, nil
+}
+
+// Decode decodes the plans of a resource from its plan info, which can be any
+// of the resource kind specific plan info types.
+func Decode(kind, refID string, planInfo interface{}) (*Resource, error) {
+	b, err := json.Marshal(planInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans = Resource{Kind: kind, RefID: refID}
+	if err := json.Unmarshal(b, &plans); err != nil {
+		return nil, fmt.Errorf("failed decoding %s [%s] plans: %w", kind, refID, err)
+	}
+	return &plans, nil
+}
+
+// Last returns the last attempted plan of the resource, which is the last
+// plan in its history, falling back to the current plan.
+func (r *Resource) Last() *Attempt {
+	if n := len(r.History); n > 0 {
+		return r.History[n-1]
+	}
+	return r.Current
+}
+
+// Version returns the version of the resource's current plan, or an empty
+// string when the resource has no current plan.
+func (r *Resource) Version() string {
+	if r.Current == nil || len(r.Current.Plan) == 0 {
+		return ""
+	}
+
+	var plan map[string]struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(r.Current.Plan, &plan); err != nil {
+		return ""
+	}
+	return plan[r.Kind].Version
+}
+
+// Name returns the plan attempt name, falling back to its ID.
+func (a *Attempt) Name() string {
+	if a.AttemptName != "" {
+		return a.AttemptName
+	}
+	return a.AttemptID
+}
+
+// FailedStep returns the ID of the first plan step which errored, or an empty
+// string when none of the steps errored.
+func (a *Attempt) FailedStep() string { return FailedStepName(a.PlanAttemptLog) }
+
+// Duration returns the duration of the plan attempt, or an empty string when
+// the attempt hasn't started or finished.
+func (a *Attempt) Duration() string { return Duration(a.AttemptStartTime, a.AttemptEndTime) }
+
+// FailedStepName returns the ID of the first plan step which errored, ignoring
+// the final "plan-completed" step. It returns an empty string when none of the
+// steps errored.
+func FailedStepName(log []*models.ClusterPlanStepInfo) string {
+	for _, step := range log {
+		if step.Status != nil && step.StepID != nil &&
+			*step.Status == "error" && *step.StepID != "plan-completed" {
+			return *step.StepID
+		}
+	}
+	return ""
+}
+
+// Duration returns the duration of a plan attempt from its start and end times.
+// It returns an empty string when the attempt hasn't started or finished.
+func Duration(start, end strfmt.DateTime) string {
+	startTime, endTime := time.Time(start), time.Time(end)
+	if startTime.IsZero() || endTime.IsZero() {
+		return ""
+	}
+	return endTime.Sub(startTime).String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
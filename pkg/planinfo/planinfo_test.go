@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+)
+
+func TestDuration(t *testing.T) {
+	start := strfmt.DateTime(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC))
+	end := strfmt.DateTime(time.Date(2024, 6, 1, 10, 3, 30, 0, time.UTC))
+	tests := []struct {
+		name       string
+		start, end strfmt.DateTime
+		want       string
+	}{
+		{
+			name:  "returns the duration of a finished attempt",
+			start: start,
+			end:   end,
+			want:  "3m30s",
+		},
+		{
+			name:  "returns an empty string when the attempt hasn't finished",
+			start: start,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Duration(tt.start, tt.end); got != tt.want {
+				t.Errorf("Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	planInfo := &models.KibanaClusterPlansInfo{
+		Current: &models.KibanaClusterPlanInfo{
+			Healthy: ec.Bool(true),
+			Plan: &models.KibanaClusterPlan{
+				Kibana: &models.KibanaConfiguration{Version: "8.15.0"},
+			},
+		},
+		History: []*models.KibanaClusterPlanInfo{
+			{Healthy: ec.Bool(true), PlanAttemptID: "attempt-0000000000"},
+			{
+				Healthy:       ec.Bool(false),
+				PlanAttemptID: "attempt-0000000001",
+				PlanAttemptLog: []*models.ClusterPlanStepInfo{
+					{StepID: ec.String("validate-plan"), Status: ec.String("success")},
+					{StepID: ec.String("allocate-instances"), Status: ec.String("error")},
+					{StepID: ec.String("plan-completed"), Status: ec.String("error")},
+				},
+			},
+		},
+	}
+
+	plans, err := Decode("kibana", "main-kibana", planInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := plans.Version(); got != "8.15.0" {
+		t.Errorf("Version() = %v, want %v", got, "8.15.0")
+	}
+	last := plans.Last()
+	if got := last.Name(); got != "attempt-0000000001" {
+		t.Errorf("Last().Name() = %v, want %v", got, "attempt-0000000001")
+	}
+	if got := last.FailedStep(); got != "allocate-instances" {
+		t.Errorf("Last().FailedStep() = %v, want %v", got, "allocate-instances")
+	}
+}
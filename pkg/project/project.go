@@ -105,6 +105,13 @@ type ListParams struct {
 	Host   string
 	Type   string
 	Client *http.Client
+
+	// MaxResults limits the number of listed projects. 0 means no limit.
+	MaxResults int
+
+	// OnPage is called with each page of projects as soon as it's received.
+	// When set, the projects aren't accumulated in the returned ListResult.
+	OnPage func(pt ProjectType, projects []Project) error
 }
 
 // Validate ensures the parameters are usable.
@@ -143,17 +150,34 @@ func List(params ListParams) (*ListResult, error) {
 	}
 
 	var result ListResult
+	var count int
 	for _, pt := range types {
-		resp, err := listByType(params, pt)
+		err := listByType(params, pt, func(items []Project) (bool, error) {
+			if params.MaxResults > 0 && count+len(items) > params.MaxResults {
+				items = items[:params.MaxResults-count]
+			}
+			count += len(items)
+			for i := range items {
+				if items[i].Type == "" {
+					items[i].Type = string(pt)
+				}
+			}
+
+			if params.OnPage != nil {
+				if err := params.OnPage(pt, items); err != nil {
+					return false, err
+				}
+			} else {
+				result.Projects = append(result.Projects, items...)
+			}
+			return params.MaxResults == 0 || count < params.MaxResults, nil
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to list %s projects: %w", pt, err)
 		}
-		for i := range resp.Items {
-			if resp.Items[i].Type == "" {
-				resp.Items[i].Type = string(pt)
-			}
+		if params.MaxResults > 0 && count >= params.MaxResults {
+			break
 		}
-		result.Projects = append(result.Projects, resp.Items...)
 	}
 
 	return &result, nil
@@ -485,22 +509,19 @@ func newRequest(method, endpoint string, body io.Reader) (*http.Request, error)
 	return req, nil
 }
 
-func listByType(params ListParams, pt ProjectType) (*ListResponse, error) {
-	const maxPages = 100
-
+// listByType lists the projects of the specified type, calling fn with each of
+// the pages. The listing stops when fn returns false or an error, or when the
+// API returns an empty page, no next page or the same next page cursor again.
+func listByType(params ListParams, pt ProjectType, fn func([]Project) (bool, error)) error {
 	host := strings.TrimRight(params.Host, "/")
 	baseEndpoint := fmt.Sprintf("%s/api/v1/serverless/projects/%s", host, pt)
 
-	var allItems []Project
 	cursor := ""
 
-	for page := 0; ; page++ {
-		if page >= maxPages {
-			return nil, fmt.Errorf("exceeded maximum number of pages (%d) while listing %s projects", maxPages, pt)
-		}
+	for {
 		reqURL, err := url.Parse(baseEndpoint)
 		if err != nil {
-			return nil, fmt.Errorf("invalid base endpoint URL: %w", err)
+			return fmt.Errorf("invalid base endpoint URL: %w", err)
 		}
 
 		if cursor != "" {
@@ -511,38 +532,39 @@ func listByType(params ListParams, pt ProjectType) (*ListResponse, error) {
 
 		req, err := newRequest(http.MethodGet, reqURL.String(), nil)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		req = params.API.AuthWriter.AuthRequest(req)
 
 		resp, err := params.httpClient().Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
+			return fmt.Errorf("request failed: %w", err)
 		}
 
 		body, err := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return fmt.Errorf("failed to read response body: %w", err)
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+			return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 		}
 
 		var page ListResponse
 		if err := json.Unmarshal(body, &page); err != nil {
-			return nil, fmt.Errorf("failed to decode response: %w", err)
+			return fmt.Errorf("failed to decode response: %w", err)
 		}
 
-		allItems = append(allItems, page.Items...)
+		more, err := fn(page.Items)
+		if err != nil {
+			return err
+		}
 
-		if page.NextPage == "" {
-			break
+		if !more || len(page.Items) == 0 || page.NextPage == "" || page.NextPage == cursor {
+			return nil
 		}
 		cursor = page.NextPage
 	}
-
-	return &ListResponse{Items: allItems}, nil
 }
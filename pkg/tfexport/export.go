@@ -0,0 +1,627 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package tfexport exports deployments as configuration for the Elastic Cloud
+// Terraform provider, so that existing deployments can be imported into
+// Terraform without being re-created.
+package tfexport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/extensionapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// Supported ways of importing the existing resources into Terraform.
+const (
+	// ImportBlock writes Terraform import blocks, supported by Terraform 1.5+.
+	ImportBlock = "block"
+	// ImportCommand writes the terraform import commands as comments.
+	ImportCommand = "command"
+	// ImportNone doesn't write any import blocks or commands.
+	ImportNone = "none"
+)
+
+// ImportModes contains all the supported import modes.
+var ImportModes = []string{ImportBlock, ImportCommand, ImportNone}
+
+const (
+	deploymentType         = "ec_deployment"
+	extensionType          = "ec_deployment_extension"
+	trafficFilterType      = "ec_deployment_traffic_filter"
+	trafficFilterAssocType = "ec_deployment_traffic_filter_association"
+
+	extensionURLPrefix = "repo://"
+	memoryResource     = "memory"
+)
+
+// tiers maps the Elasticsearch topology element IDs to the Terraform provider
+// tier attributes, in the order that they're written.
+var tiers = []struct{ id, name string }{
+	{"hot_content", "hot"},
+	{"warm", "warm"},
+	{"cold", "cold"},
+	{"frozen", "frozen"},
+	{"master", "master"},
+	{"coordinating", "coordinating"},
+	{"ml", "ml"},
+}
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+	identifier       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+)
+
+// Params is consumed by Export.
+type Params struct {
+	API          *api.API
+	DeploymentID string
+
+	// Import is one of the supported import modes, defaults to ImportBlock.
+	Import string
+}
+
+// Validate ensures the parameters are usable by Export.
+func (params Params) Validate() error {
+	var merr = multierror.NewPrefixed("invalid terraform export params")
+	if params.API == nil {
+		merr = merr.Append(errors.New("api reference is required"))
+	}
+
+	if len(params.DeploymentID) != 32 {
+		merr = merr.Append(errors.New("deployment id must consist of 32 characters"))
+	}
+
+	switch params.Import {
+	case "", ImportBlock, ImportCommand, ImportNone:
+	default:
+		merr = merr.Append(fmt.Errorf(`invalid import mode "%s", must be one of [%s]`,
+			params.Import, strings.Join(ImportModes, "|"),
+		))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Export writes the Terraform configuration of the deployment, its traffic
+// filters and their associations, and the custom extensions which it uses.
+func Export(w io.Writer, params Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API:          params.API,
+		DeploymentID: params.DeploymentID,
+		QueryParams: deputil.QueryParams{
+			ShowPlans:    true,
+			ShowSettings: true,
+			ShowMetadata: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var filters []*models.TrafficFilterRulesetInfo
+	if res.Settings != nil && res.Settings.TrafficFilterSettings != nil {
+		for _, id := range res.Settings.TrafficFilterSettings.Rulesets {
+			f, err := trafficfilterapi.Get(trafficfilterapi.GetParams{API: params.API, ID: id})
+			if err != nil {
+				return err
+			}
+			filters = append(filters, f)
+		}
+	}
+
+	var extensions = make(map[string]*models.Extension)
+	for _, ext := range elasticsearchExtensions(res) {
+		id := strings.TrimPrefix(ext.url, extensionURLPrefix)
+		if id == ext.url || extensions[id] != nil {
+			continue
+		}
+
+		e, err := extensionapi.Get(extensionapi.GetParams{API: params.API, ExtensionID: id})
+		if err != nil {
+			return err
+		}
+		extensions[id] = e
+	}
+
+	return write(w, configuration{
+		deployment:     res,
+		trafficFilters: filters,
+		extensions:     extensions,
+		importMode:     params.Import,
+	})
+}
+
+// configuration contains everything that's written as Terraform resources.
+type configuration struct {
+	deployment     *models.DeploymentGetResponse
+	trafficFilters []*models.TrafficFilterRulesetInfo
+	// extensions by ID.
+	extensions map[string]*models.Extension
+	importMode string
+}
+
+// importedResource is a resource that's imported with the ID.
+type importedResource struct {
+	address string
+	id      string
+}
+
+type extension struct {
+	name, kind, version, url string
+}
+
+func write(w io.Writer, c configuration) error {
+	var names = make(map[string]bool)
+	var blocks []block
+	var imports []importedResource
+
+	var extensionNames = make(map[string]string)
+	for _, ext := range elasticsearchExtensions(c.deployment) {
+		id := strings.TrimPrefix(ext.url, extensionURLPrefix)
+		e, ok := c.extensions[id]
+		if !ok || extensionNames[id] != "" {
+			continue
+		}
+
+		name := uniqueName(names, extensionType, stringValue(e.Name), "extension")
+		extensionNames[id] = name
+		blocks = append(blocks, extensionBlock(name, e))
+		imports = append(imports, importedResource{extensionType + "." + name, id})
+	}
+
+	var filterNames = make([]string, 0, len(c.trafficFilters))
+	for _, f := range c.trafficFilters {
+		name := uniqueName(names, trafficFilterType, stringValue(f.Name), "traffic_filter")
+		filterNames = append(filterNames, name)
+		blocks = append(blocks, trafficFilterBlock(name, f))
+		imports = append(imports, importedResource{trafficFilterType + "." + name, stringValue(f.ID)})
+	}
+
+	deploymentName := uniqueName(names, deploymentType, stringValue(c.deployment.Name), "deployment")
+	blocks = append(blocks, deploymentBlock(deploymentName, c.deployment, extensionNames, len(filterNames) > 0))
+	imports = append([]importedResource{{deploymentType + "." + deploymentName, stringValue(c.deployment.ID)}}, imports...)
+
+	for _, name := range filterNames {
+		blocks = append(blocks, block{
+			Type:   "resource",
+			Labels: []string{trafficFilterAssocType, name},
+			Body: []attribute{
+				attr("traffic_filter_id", expression(trafficFilterType+"."+name+".id")),
+				attr("deployment_id", expression(deploymentType+"."+deploymentName+".id")),
+			},
+		})
+	}
+
+	switch c.importMode {
+	case "", ImportBlock:
+		for _, r := range imports {
+			blocks = append(blocks, block{Type: "import", Body: []attribute{
+				attr("to", expression(r.address)),
+				attr("id", r.id),
+			}})
+		}
+	case ImportCommand:
+		var sb strings.Builder
+		sb.WriteString("# Import the existing resources into the Terraform state with:\n")
+		for _, r := range imports {
+			fmt.Fprintf(&sb, "#   terraform import %s %s\n", r.address, r.id)
+		}
+		sb.WriteString("\n")
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return err
+		}
+	}
+
+	return writeBlocks(w, blocks)
+}
+
+func deploymentBlock(name string, res *models.DeploymentGetResponse, extensionNames map[string]string, ignoreFilters bool) block {
+	var body = []attribute{attr("name", stringValue(res.Name))}
+	if res.Alias != "" {
+		body = append(body, attr("alias", res.Alias))
+	}
+
+	var resources = res.Resources
+	if resources == nil {
+		resources = &models.DeploymentResources{}
+	}
+
+	for _, r := range resources.Elasticsearch {
+		plan := elasticsearchPlan(r)
+		if plan == nil {
+			continue
+		}
+
+		body = append(body, attr("region", stringValue(r.Region)))
+		if plan.Elasticsearch != nil && plan.Elasticsearch.Version != "" {
+			body = append(body, attr("version", plan.Elasticsearch.Version))
+		}
+		if plan.DeploymentTemplate != nil && plan.DeploymentTemplate.ID != nil {
+			body = append(body, attr("deployment_template_id", *plan.DeploymentTemplate.ID))
+		}
+		if tags := tagsObject(res.Metadata); len(tags) > 0 {
+			body = append(body, attr("tags", tags))
+		}
+		body = append(body, attr("elasticsearch", elasticsearchObject(r, plan, extensionNames)))
+		break
+	}
+
+	for _, r := range statelessResources(resources) {
+		body = append(body, attr(r.kind, r.object()))
+	}
+
+	// The traffic filters are managed by the association resources, which
+	// conflict with the traffic_filter attribute.
+	if ignoreFilters {
+		body = append(body, nestedBlock(block{Type: "lifecycle", Body: []attribute{
+			attr("ignore_changes", []interface{}{expression("traffic_filter")}),
+		}}))
+	}
+
+	return block{Type: "resource", Labels: []string{deploymentType, name}, Body: body}
+}
+
+func elasticsearchObject(r *models.ElasticsearchResourceInfo, plan *models.ElasticsearchClusterPlan, extensionNames map[string]string) object {
+	var obj object
+	if refID := stringValue(r.RefID); refID != "" && refID != "main-elasticsearch" {
+		obj = append(obj, attr("ref_id", refID))
+	}
+
+	var autoscale = plan.AutoscalingEnabled != nil && *plan.AutoscalingEnabled
+	if plan.AutoscalingEnabled != nil {
+		obj = append(obj, attr("autoscale", autoscale))
+	}
+
+	if plan.Elasticsearch != nil && plan.Elasticsearch.UserSettingsYaml != "" {
+		obj = append(obj, attr("config", object{attr("user_settings_yaml", plan.Elasticsearch.UserSettingsYaml)}))
+	}
+
+	for _, t := range tiers {
+		for _, e := range plan.ClusterTopology {
+			if e == nil || e.ID != t.id {
+				continue
+			}
+
+			hasSize := e.Size != nil && e.Size.Value != nil && *e.Size.Value > 0
+			canScale := autoscale && e.AutoscalingMax != nil && e.AutoscalingMax.Value != nil && *e.AutoscalingMax.Value > 0
+			if hasSize || canScale {
+				obj = append(obj, attr(t.name, tierObject(e, autoscale)))
+			}
+		}
+	}
+
+	var extensions []interface{}
+	for _, ext := range planExtensions(plan) {
+		var extObj = object{
+			attr("name", ext.name),
+			attr("type", ext.kind),
+			attr("version", ext.version),
+			attr("url", ext.url),
+		}
+		if name, ok := extensionNames[strings.TrimPrefix(ext.url, extensionURLPrefix)]; ok {
+			extObj[0].Value = expression(extensionType + "." + name + ".name")
+			extObj[3].Value = expression(extensionType + "." + name + ".url")
+		}
+		extensions = append(extensions, extObj)
+	}
+	if len(extensions) > 0 {
+		obj = append(obj, attr("extension", extensions))
+	}
+
+	return obj
+}
+
+func tierObject(e *models.ElasticsearchClusterTopologyElement, autoscale bool) object {
+	var obj object
+	if e.Size != nil && e.Size.Value != nil {
+		obj = append(obj, attr("size", formatSize(*e.Size.Value)))
+		if res := stringValue(e.Size.Resource); res != "" && res != memoryResource {
+			obj = append(obj, attr("size_resource", res))
+		}
+	}
+	obj = append(obj, attr("zone_count", e.ZoneCount))
+
+	var autoscaling object
+	if autoscale {
+		autoscaling = append(autoscaling, sizeAttributes("max_size", e.AutoscalingMax)...)
+		autoscaling = append(autoscaling, sizeAttributes("min_size", e.AutoscalingMin)...)
+	}
+
+	return append(obj, attr("autoscaling", autoscaling))
+}
+
+// sizeAttributes returns the size attribute, and its resource attribute when
+// the size isn't in memory.
+func sizeAttributes(name string, size *models.TopologySize) []attribute {
+	if size == nil || size.Value == nil || *size.Value == 0 {
+		return nil
+	}
+
+	var attrs = []attribute{attr(name, formatSize(*size.Value))}
+	if res := stringValue(size.Resource); res != "" && res != memoryResource {
+		attrs = append(attrs, attr(name+"_resource", res))
+	}
+	return attrs
+}
+
+// statelessResource contains the attributes of the Kibana, APM, Integrations
+// Server and Enterprise Search resources.
+type statelessResource struct {
+	kind         string
+	refID        string
+	esRefID      string
+	size         *models.TopologySize
+	zoneCount    int32
+	userSettings string
+}
+
+func (r statelessResource) object() object {
+	var obj object
+	if r.refID != "" && r.refID != "main-"+r.kind {
+		obj = append(obj, attr("ref_id", r.refID))
+	}
+	if r.esRefID != "" && r.esRefID != "main-elasticsearch" {
+		obj = append(obj, attr("elasticsearch_cluster_ref_id", r.esRefID))
+	}
+
+	obj = append(obj, sizeAttributes("size", r.size)...)
+	if r.zoneCount > 0 {
+		obj = append(obj, attr("zone_count", r.zoneCount))
+	}
+
+	if r.userSettings != "" {
+		obj = append(obj, attr("config", object{attr("user_settings_yaml", r.userSettings)}))
+	}
+	return obj
+}
+
+// statelessResources returns the stateless resources with a current plan, in
+// the Kibana, APM, Integrations Server and Enterprise Search order.
+func statelessResources(resources *models.DeploymentResources) []statelessResource {
+	var result []statelessResource
+	for _, r := range resources.Kibana {
+		if r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Current == nil || r.Info.PlanInfo.Current.Plan == nil {
+			continue
+		}
+		plan := r.Info.PlanInfo.Current.Plan
+		res := statelessResource{kind: "kibana", refID: stringValue(r.RefID), esRefID: stringValue(r.ElasticsearchClusterRefID)}
+		if len(plan.ClusterTopology) > 0 {
+			res.size, res.zoneCount = plan.ClusterTopology[0].Size, plan.ClusterTopology[0].ZoneCount
+		}
+		if plan.Kibana != nil {
+			res.userSettings = plan.Kibana.UserSettingsYaml
+		}
+		result = append(result, res)
+	}
+
+	for _, r := range resources.Apm {
+		if r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Current == nil || r.Info.PlanInfo.Current.Plan == nil {
+			continue
+		}
+		plan := r.Info.PlanInfo.Current.Plan
+		res := statelessResource{kind: "apm", refID: stringValue(r.RefID), esRefID: stringValue(r.ElasticsearchClusterRefID)}
+		if len(plan.ClusterTopology) > 0 {
+			res.size, res.zoneCount = plan.ClusterTopology[0].Size, plan.ClusterTopology[0].ZoneCount
+		}
+		if plan.Apm != nil {
+			res.userSettings = plan.Apm.UserSettingsYaml
+		}
+		result = append(result, res)
+	}
+
+	for _, r := range resources.IntegrationsServer {
+		if r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Current == nil || r.Info.PlanInfo.Current.Plan == nil {
+			continue
+		}
+		plan := r.Info.PlanInfo.Current.Plan
+		res := statelessResource{kind: "integrations_server", refID: stringValue(r.RefID), esRefID: stringValue(r.ElasticsearchClusterRefID)}
+		if len(plan.ClusterTopology) > 0 {
+			res.size, res.zoneCount = plan.ClusterTopology[0].Size, plan.ClusterTopology[0].ZoneCount
+		}
+		if plan.IntegrationsServer != nil {
+			res.userSettings = plan.IntegrationsServer.UserSettingsYaml
+		}
+		result = append(result, res)
+	}
+
+	for _, r := range resources.EnterpriseSearch {
+		if r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Current == nil || r.Info.PlanInfo.Current.Plan == nil {
+			continue
+		}
+		plan := r.Info.PlanInfo.Current.Plan
+		res := statelessResource{kind: "enterprise_search", refID: stringValue(r.RefID), esRefID: stringValue(r.ElasticsearchClusterRefID)}
+		if len(plan.ClusterTopology) > 0 {
+			res.size, res.zoneCount = plan.ClusterTopology[0].Size, plan.ClusterTopology[0].ZoneCount
+		}
+		if plan.EnterpriseSearch != nil {
+			res.userSettings = plan.EnterpriseSearch.UserSettingsYaml
+		}
+		result = append(result, res)
+	}
+
+	return result
+}
+
+func extensionBlock(name string, e *models.Extension) block {
+	var body = []attribute{attr("name", stringValue(e.Name))}
+	if e.Description != "" {
+		body = append(body, attr("description", e.Description))
+	}
+	body = append(body,
+		attr("version", stringValue(e.Version)),
+		attr("extension_type", stringValue(e.ExtensionType)),
+	)
+	if e.DownloadURL != "" {
+		body = append(body, attr("download_url", e.DownloadURL))
+	}
+
+	return block{Type: "resource", Labels: []string{extensionType, name}, Body: body}
+}
+
+func trafficFilterBlock(name string, f *models.TrafficFilterRulesetInfo) block {
+	var body = []attribute{
+		attr("name", stringValue(f.Name)),
+		attr("region", stringValue(f.Region)),
+		attr("type", stringValue(f.Type)),
+	}
+	if f.IncludeByDefault != nil {
+		body = append(body, attr("include_by_default", *f.IncludeByDefault))
+	}
+	if f.Description != "" {
+		body = append(body, attr("description", f.Description))
+	}
+
+	for _, r := range f.Rules {
+		if r == nil {
+			continue
+		}
+
+		var rule []attribute
+		if r.Source != "" {
+			rule = append(rule, attr("source", r.Source))
+		}
+		if r.Description != "" {
+			rule = append(rule, attr("description", r.Description))
+		}
+		if r.AzureEndpointName != "" {
+			rule = append(rule, attr("azure_endpoint_name", r.AzureEndpointName))
+		}
+		if r.AzureEndpointGUID != "" {
+			rule = append(rule, attr("azure_endpoint_guid", r.AzureEndpointGUID))
+		}
+		body = append(body, nestedBlock(block{Type: "rule", Body: rule}))
+	}
+
+	return block{Type: "resource", Labels: []string{trafficFilterType, name}, Body: body}
+}
+
+func tagsObject(metadata *models.DeploymentMetadata) object {
+	if metadata == nil {
+		return nil
+	}
+
+	var tags object
+	for _, t := range metadata.Tags {
+		if t == nil || t.Key == nil {
+			continue
+		}
+
+		key := *t.Key
+		if !identifier.MatchString(key) {
+			key = quote(key)
+		}
+		tags = append(tags, attr(key, stringValue(t.Value)))
+	}
+	return tags
+}
+
+// elasticsearchExtensions returns the extensions of the current Elasticsearch
+// plans.
+func elasticsearchExtensions(res *models.DeploymentGetResponse) []extension {
+	if res.Resources == nil {
+		return nil
+	}
+
+	var extensions []extension
+	for _, r := range res.Resources.Elasticsearch {
+		if plan := elasticsearchPlan(r); plan != nil {
+			extensions = append(extensions, planExtensions(plan)...)
+		}
+	}
+	return extensions
+}
+
+// planExtensions returns the bundles and plugins of the Elasticsearch plan.
+func planExtensions(plan *models.ElasticsearchClusterPlan) []extension {
+	if plan.Elasticsearch == nil {
+		return nil
+	}
+
+	var extensions []extension
+	for _, b := range plan.Elasticsearch.UserBundles {
+		if b != nil {
+			extensions = append(extensions, extension{
+				name: stringValue(b.Name), kind: "bundle",
+				version: stringValue(b.ElasticsearchVersion), url: stringValue(b.URL),
+			})
+		}
+	}
+	for _, p := range plan.Elasticsearch.UserPlugins {
+		if p != nil {
+			extensions = append(extensions, extension{
+				name: stringValue(p.Name), kind: "plugin",
+				version: stringValue(p.ElasticsearchVersion), url: stringValue(p.URL),
+			})
+		}
+	}
+	return extensions
+}
+
+func elasticsearchPlan(r *models.ElasticsearchResourceInfo) *models.ElasticsearchClusterPlan {
+	if r == nil || r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Current == nil {
+		return nil
+	}
+	return r.Info.PlanInfo.Current.Plan
+}
+
+// formatSize formats the size in MB in the gigabyte notation which is used by
+// the Terraform provider, e.g. 512 is formatted as 0.5g.
+func formatSize(mb int32) string {
+	return strconv.FormatFloat(float64(mb)/1024, 'f', -1, 64) + "g"
+}
+
+// uniqueName returns a valid Terraform resource name from the name, which is
+// unique for the resource type.
+func uniqueName(names map[string]bool, resourceType, name, fallback string) string {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		name = fallback
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = fallback + "_" + name
+	}
+
+	var unique = name
+	for i := 2; names[resourceType+"."+unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	names[resourceType+"."+unique] = true
+	return unique
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
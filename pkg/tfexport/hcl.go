@@ -0,0 +1,228 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tfexport
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// block is an HCL block, such as a resource or a nested block like lifecycle.
+type block struct {
+	Type   string
+	Labels []string
+	Body   []attribute
+}
+
+// attribute is either an attribute or a nested block of an HCL body. Values
+// can be strings, integers, booleans, expressions, lists and objects.
+type attribute struct {
+	Name  string
+	Value interface{}
+	Block *block
+}
+
+// expression is an HCL expression which is written verbatim, such as a
+// reference to the attribute of another resource.
+type expression string
+
+// object is an HCL object whose attributes are written in order.
+type object []attribute
+
+func attr(name string, value interface{}) attribute {
+	return attribute{Name: name, Value: value}
+}
+
+func nestedBlock(b block) attribute {
+	return attribute{Block: &b}
+}
+
+// writeBlocks writes the blocks separated by an empty line.
+func writeBlocks(w io.Writer, blocks []block) error {
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		writeBlock(&sb, b, 0)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeBlock(sb *strings.Builder, b block, depth int) {
+	sb.WriteString(indent(depth) + b.Type)
+	for _, l := range b.Labels {
+		sb.WriteString(" " + quote(l))
+	}
+	sb.WriteString(" {\n")
+	writeBody(sb, b.Body, depth+1)
+	sb.WriteString(indent(depth) + "}\n")
+}
+
+// writeBody writes the attributes aligning the equals signs of consecutive
+// single line attributes, the same way that terraform fmt does. Multi-line
+// attributes and nested blocks are separated by an empty line.
+func writeBody(sb *strings.Builder, attrs []attribute, depth int) {
+	for i := 0; i < len(attrs); {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		if attrs[i].Block != nil || multiline(attrs[i].Value) {
+			if b := attrs[i].Block; b != nil {
+				writeBlock(sb, *b, depth)
+			} else {
+				sb.WriteString(indent(depth) + attrs[i].Name + " = ")
+				writeValue(sb, attrs[i].Value, depth)
+				sb.WriteString("\n")
+			}
+			i++
+			continue
+		}
+
+		var end, width = i, 0
+		for ; end < len(attrs) && attrs[end].Block == nil && !multiline(attrs[end].Value); end++ {
+			if len(attrs[end].Name) > width {
+				width = len(attrs[end].Name)
+			}
+		}
+
+		for _, a := range attrs[i:end] {
+			sb.WriteString(indent(depth) + a.Name + strings.Repeat(" ", width-len(a.Name)) + " = ")
+			writeValue(sb, a.Value, depth)
+			sb.WriteString("\n")
+		}
+		i = end
+	}
+}
+
+func writeValue(sb *strings.Builder, v interface{}, depth int) {
+	switch value := v.(type) {
+	case string:
+		if heredoc(value) {
+			writeHeredoc(sb, value, depth)
+			return
+		}
+		sb.WriteString(quote(value))
+	case expression:
+		sb.WriteString(string(value))
+	case int:
+		sb.WriteString(strconv.Itoa(value))
+	case int32:
+		sb.WriteString(strconv.Itoa(int(value)))
+	case bool:
+		sb.WriteString(strconv.FormatBool(value))
+	case object:
+		if len(value) == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{\n")
+		writeBody(sb, value, depth+1)
+		sb.WriteString(indent(depth) + "}")
+	case []interface{}:
+		if !multiline(value) {
+			sb.WriteString("[")
+			for i, item := range value {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				writeValue(sb, item, depth)
+			}
+			sb.WriteString("]")
+			return
+		}
+
+		sb.WriteString("[\n")
+		for _, item := range value {
+			sb.WriteString(indent(depth + 1))
+			writeValue(sb, item, depth+1)
+			sb.WriteString(",\n")
+		}
+		sb.WriteString(indent(depth) + "]")
+	default:
+		panic(fmt.Sprintf("tfexport: unsupported value type %T", v))
+	}
+}
+
+// heredoc returns true when the string can be written as an indented heredoc
+// without changing its value: it must have multiple lines which end with a
+// newline, none of them can be the heredoc delimiter, and at least one of them
+// must not be indented since the common indentation is removed.
+func heredoc(s string) bool {
+	if !strings.HasSuffix(s, "\n") || strings.Count(s, "\n") < 2 || strings.Contains(s, "\r") {
+		return false
+	}
+
+	var unindented bool
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		if strings.TrimSpace(line) == "EOT" {
+			return false
+		}
+		if line != "" && line[0] != ' ' && line[0] != '\t' {
+			unindented = true
+		}
+	}
+	return unindented
+}
+
+// writeHeredoc writes multi-line strings as an indented heredoc.
+func writeHeredoc(sb *strings.Builder, s string, depth int) {
+	sb.WriteString("<<-EOT\n")
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		if line != "" {
+			sb.WriteString(indent(depth+1) + escapeTemplate(line))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent(depth+1) + "EOT")
+}
+
+func multiline(v interface{}) bool {
+	switch value := v.(type) {
+	case string:
+		return heredoc(value)
+	case object:
+		return len(value) > 0
+	case []interface{}:
+		for _, item := range value {
+			if multiline(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func quote(s string) string {
+	var r = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + escapeTemplate(r.Replace(s)) + `"`
+}
+
+// escapeTemplate escapes the HCL template sequences.
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
+
+func indent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
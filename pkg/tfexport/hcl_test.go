@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tfexport
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBlocks(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeBlocks(&buf, []block{
+		{Type: "resource", Labels: []string{"ec_deployment", "example"}, Body: []attribute{
+			attr("name", `my "quoted" ${name}`),
+			attr("zone_count", 2),
+			attr("tags", object{attr("team", "search")}),
+			attr("autoscale", true),
+			attr("indented", "  a: 1\n  b: 2\n"),
+			attr("settings", "a: 1\nb:\n  c: 2\n"),
+			attr("ids", []interface{}{expression("ec_deployment_traffic_filter.example.id"), "b"}),
+			nestedBlock(block{Type: "lifecycle", Body: []attribute{
+				attr("ignore_changes", []interface{}{expression("traffic_filter")}),
+			}}),
+		}},
+		{Type: "import", Body: []attribute{attr("to", expression("ec_deployment.example")), attr("id", "abc")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `resource "ec_deployment" "example" {
+  name       = "my \"quoted\" $${name}"
+  zone_count = 2
+
+  tags = {
+    team = "search"
+  }
+
+  autoscale = true
+  indented  = "  a: 1\n  b: 2\n"
+
+  settings = <<-EOT
+    a: 1
+    b:
+      c: 2
+    EOT
+
+  ids = [ec_deployment_traffic_filter.example.id, "b"]
+
+  lifecycle {
+    ignore_changes = [traffic_filter]
+  }
+}
+
+import {
+  to = ec_deployment.example
+  id = "abc"
+}
+`, buf.String())
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "0.5g", formatSize(512))
+	assert.Equal(t, "8g", formatSize(8192))
+	assert.Equal(t, "1.5g", formatSize(1536))
+}
+
+func TestUniqueName(t *testing.T) {
+	var names = make(map[string]bool)
+	assert.Equal(t, "my_deployment", uniqueName(names, deploymentType, "My Deployment!", "deployment"))
+	assert.Equal(t, "my_deployment_2", uniqueName(names, deploymentType, "my-deployment", "deployment"))
+	assert.Equal(t, "my_deployment", uniqueName(names, trafficFilterType, "my deployment", "traffic_filter"))
+	assert.Equal(t, "traffic_filter_1234", uniqueName(names, trafficFilterType, "1234", "traffic_filter"))
+	assert.Equal(t, "extension", uniqueName(names, extensionType, "!!!", "extension"))
+}